@@ -0,0 +1,30 @@
+terraform {
+  required_providers {
+    neon = {
+      source = "example.org/virtual-repetitions/neon"
+    }
+  }
+}
+
+provider "neon" {}
+
+variable "environment" {
+  type    = string
+  default = "staging"
+}
+
+data "neon_project" "shared" {
+  name = "shared-project"
+}
+
+# Find the branch of the environment in a project managed by another stack.
+data "neon_branch" "environment" {
+  project_id = data.neon_project.shared.id
+  name       = var.environment
+}
+
+resource "neon_endpoint" "environment" {
+  project_id = data.neon_project.shared.id
+  branch_id  = data.neon_branch.environment.id
+  type       = "read_only"
+}
@@ -0,0 +1,23 @@
+terraform {
+  required_providers {
+    neon = {
+      source = "example.org/virtual-repetitions/neon"
+    }
+  }
+}
+
+provider "neon" {}
+
+data "neon_project" "shared" {
+  name = "shared-project"
+}
+
+data "neon_branches" "previews" {
+  project_id = data.neon_project.shared.id
+  name_regex = "^preview-[0-9]+$"
+  sort_order = "desc"
+}
+
+output "latest_preview_branch_id" {
+  value = try(data.neon_branches.previews.branches[0].id, null)
+}
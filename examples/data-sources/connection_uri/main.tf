@@ -0,0 +1,34 @@
+terraform {
+  required_providers {
+    neon = {
+      source = "example.org/virtual-repetitions/neon"
+    }
+  }
+}
+
+provider "neon" {}
+
+resource "neon_project" "app" {
+  name            = "app"
+  instance_handle = "scalable"
+  platform_id     = "aws"
+  region_id       = "aws-us-east-2"
+}
+
+# Connection strings of the default database on the default branch.
+data "neon_connection_uri" "app" {
+  project_id      = neon_project.app.id
+  role_name       = neon_project.app.default_role_name
+  database_name   = neon_project.app.default_database_name
+  channel_binding = "require"
+}
+
+output "database_url" {
+  value     = data.neon_connection_uri.app.formats["prisma_pooled"]
+  sensitive = true
+}
+
+output "django_database" {
+  value     = jsondecode(data.neon_connection_uri.app.formats["django"])
+  sensitive = true
+}
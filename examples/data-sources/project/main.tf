@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    neon = {
+      source = "example.org/virtual-repetitions/neon"
+    }
+  }
+}
+
+provider "neon" {}
+
+# Projects can be looked up by ID...
+data "neon_project" "by_id" {
+  id = "example-project-id"
+}
+
+# ...or by their exact name, as long as a single project has that name.
+data "neon_project" "by_name" {
+  name = "example-project"
+}
+
+resource "neon_branch" "example" {
+  project_id = data.neon_project.by_name.id
+  name       = "example-branch"
+}
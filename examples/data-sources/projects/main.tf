@@ -0,0 +1,18 @@
+terraform {
+  required_providers {
+    neon = {
+      source = "example.org/virtual-repetitions/neon"
+    }
+  }
+}
+
+provider "neon" {}
+
+data "neon_projects" "staging" {
+  name_prefix = "staging-"
+  region_id   = "aws-us-west-2"
+}
+
+output "staging_project_ids" {
+  value = data.neon_projects.staging.projects[*].id
+}
@@ -0,0 +1,26 @@
+terraform {
+  required_providers {
+    neon = {
+      source = "example.org/virtual-repetitions/neon"
+    }
+  }
+}
+
+provider "neon" {}
+
+data "neon_regions" "all" {}
+
+locals {
+  default_region = [for region in data.neon_regions.all.regions : region if region.default][0]
+}
+
+resource "neon_project" "example" {
+  name            = "example-project-in-default-region"
+  instance_handle = "scalable"
+  platform_id     = local.default_region.platform_id
+  region_id       = local.default_region.region_id
+}
+
+output "region_ids" {
+  value = data.neon_regions.all.regions[*].region_id
+}
@@ -0,0 +1,2 @@
+# Branches are imported by project ID and branch ID.
+terraform import neon_branch.example <project_id>/<branch_id>
@@ -16,6 +16,15 @@ resource "neon_project" "example" {
 }
 
 resource "neon_branch" "example_branch" {
-  parent_project_id = neon_project.example.id
+  project_id = neon_project.example.id
+  name       = "example-branch"
+}
+
+# Branch from the state of another branch at a point in time, for example to analyze an incident.
+resource "neon_branch" "example_incident" {
+  project_id       = neon_project.example.id
+  name             = "example-incident"
+  parent_id        = neon_branch.example_branch.id
+  parent_timestamp = "2022-11-01T10:00:00Z"
 }
 
@@ -0,0 +1,2 @@
+# Databases are imported by project ID, branch ID and database name.
+terraform import neon_database.example <project_id>/<branch_id>/<name>
@@ -0,0 +1,2 @@
+# Endpoints are imported by project ID and endpoint ID.
+terraform import neon_endpoint.example <project_id>/<endpoint_id>
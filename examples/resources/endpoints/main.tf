@@ -0,0 +1,31 @@
+terraform {
+  required_providers {
+    neon = {
+      source = "example.org/virtual-repetitions/neon"
+    }
+  }
+}
+
+provider "neon" {}
+
+resource "neon_project" "example" {
+  name            = "example-project-with-endpoints"
+  instance_handle = "scalable"
+  platform_id     = "aws"
+  region_id       = "aws-us-west-2"
+}
+
+resource "neon_branch" "example" {
+  project_id = neon_project.example.id
+  name       = "example-branch"
+}
+
+resource "neon_endpoint" "example" {
+  project_id               = neon_project.example.id
+  branch_id                = neon_branch.example.id
+  type                     = "read_write"
+  autoscaling_limit_min_cu = 0.25
+  autoscaling_limit_max_cu = 2
+  suspend_timeout_seconds  = 300
+  pooler_enabled           = true
+}
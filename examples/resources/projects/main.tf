@@ -15,3 +15,8 @@ resource "neon_project" "example" {
   region_id       = "aws-us-west-2"
 }
 
+
+output "connection_uri" {
+  value     = neon_project.example.connection_uri
+  sensitive = true
+}
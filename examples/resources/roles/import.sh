@@ -0,0 +1,2 @@
+# Roles are imported by project ID, branch ID and role name. The password is read from Neon.
+terraform import neon_role.example <project_id>/<branch_id>/<name>
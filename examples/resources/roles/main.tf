@@ -0,0 +1,30 @@
+terraform {
+  required_providers {
+    neon = {
+      source = "example.org/virtual-repetitions/neon"
+    }
+  }
+}
+
+provider "neon" {}
+
+resource "neon_project" "example" {
+  name            = "example-project-with-roles"
+  instance_handle = "scalable"
+  platform_id     = "aws"
+  region_id       = "aws-us-west-2"
+}
+
+resource "neon_branch" "example" {
+  project_id = neon_project.example.id
+  name       = "example-branch"
+}
+
+resource "neon_role" "example" {
+  project_id = neon_project.example.id
+  branch_id  = neon_branch.example.id
+  name       = "app_user"
+
+  # Change this value to have Neon generate a new password.
+  password_reset_trigger = "2022-11-01"
+}
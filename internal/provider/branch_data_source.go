@@ -0,0 +1,229 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"virtual-repetitions/terraform-provider-neon/src/neonApi"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &NeonBranchDataSource{}
+var _ datasource.DataSourceWithConfigure = &NeonBranchDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &NeonBranchDataSource{}
+
+func NewNeonBranchDataSource() datasource.DataSource {
+	return &NeonBranchDataSource{}
+}
+
+// NeonBranchDataSource defines the data source implementation. It shares its data model with the branch resource.
+type NeonBranchDataSource struct {
+	client neonApi.NeonApiClient
+}
+
+func (d *NeonBranchDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_branch"
+}
+
+func (d *NeonBranchDataSource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	attributes := branchDataSourceAttributes()
+
+	attributes["project_id"] = tfsdk.Attribute{
+		Required:            true,
+		MarkdownDescription: "ID of the project the branch belongs to.",
+		Type:                types.StringType,
+	}
+	attributes["id"] = tfsdk.Attribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: "ID of the branch to look up. Exactly one of `id` and `name` must be set.",
+		Type:                types.StringType,
+	}
+	attributes["name"] = tfsdk.Attribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: "Name of the branch to look up. Exactly one of `id` and `name` must be set.",
+		Type:                types.StringType,
+	}
+
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Looks up a Neon branch of a project by ID or name",
+
+		Attributes: attributes,
+	}, nil
+}
+
+func (d *NeonBranchDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config neonBranchResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Unknown values are only known at apply time, so they are assumed to be set.
+	if hasID, hasName := !config.ID.Null, !config.Name.Null; hasID == hasName {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"Invalid branch lookup",
+			"Exactly one of `id` and `name` must be set.",
+		)
+	}
+}
+
+func (d *NeonBranchDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(neonApi.NeonApiClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected neonApi.NeonApiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *NeonBranchDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config neonBranchResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var branch neonApi.NeonBranch
+	var ok bool
+
+	if !config.ID.Null {
+		branch, ok = d.readBranchByID(ctx, config.ProjectID.Value, config.ID.Value, &resp.Diagnostics)
+	} else {
+		branch, ok = d.readBranchByName(ctx, config.ProjectID.Value, config.Name.Value, &resp.Diagnostics)
+	}
+
+	if !ok {
+		return
+	}
+
+	state := newNeonBranchResourceModel(branch, neonBranchResourceModel{})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (d *NeonBranchDataSource) readBranchByID(ctx context.Context, projectID string, branchID string, diags *diag.Diagnostics) (neonApi.NeonBranch, bool) {
+	branch, err := d.client.BranchRead(ctx, projectID, branchID, neonApi.NeonApiClientOptions{})
+
+	if neonApi.IsNotFound(err) {
+		diags.AddError(
+			"Branch not found",
+			fmt.Sprintf("Branch %s does not exist in project %s.", branchID, projectID),
+		)
+		return branch, false
+	}
+
+	if err != nil {
+		diags.AddError(
+			"Error reading branch",
+			"Could not read branch, unexpected error: "+err.Error(),
+		)
+		return branch, false
+	}
+
+	return branch, true
+}
+
+func (d *NeonBranchDataSource) readBranchByName(ctx context.Context, projectID string, name string, diags *diag.Diagnostics) (neonApi.NeonBranch, bool) {
+	branches, err := d.client.BranchList(ctx, projectID, neonApi.NeonApiClientOptions{})
+
+	if err != nil {
+		diags.AddError(
+			"Error reading branch",
+			"Could not list branches, unexpected error: "+err.Error(),
+		)
+		return neonApi.NeonBranch{}, false
+	}
+
+	for _, branch := range branches {
+		if branch.Name == name {
+			return branch, true
+		}
+	}
+
+	diags.AddError(
+		"Branch not found",
+		fmt.Sprintf("No branch of project %s is named %s.", projectID, name),
+	)
+	return neonApi.NeonBranch{}, false
+}
+
+// branchDataSourceAttributes returns the computed attributes describing a branch.
+func branchDataSourceAttributes() map[string]tfsdk.Attribute {
+	return map[string]tfsdk.Attribute{
+		"id": {
+			Computed:            true,
+			MarkdownDescription: "Branch ID",
+			Type:                types.StringType,
+		},
+		"project_id": {
+			Computed:            true,
+			MarkdownDescription: "ID of the project the branch belongs to.",
+			Type:                types.StringType,
+		},
+		"name": {
+			Computed:            true,
+			MarkdownDescription: "Branch name.",
+			Type:                types.StringType,
+		},
+		"parent_id": {
+			Computed:            true,
+			MarkdownDescription: "ID of the branch the branch was created from. Null for the root branch.",
+			Type:                types.StringType,
+		},
+		"parent_lsn": {
+			Computed:            true,
+			MarkdownDescription: "Log sequence number of the parent branch the branch was created from.",
+			Type:                types.StringType,
+		},
+		"parent_timestamp": {
+			Computed:            true,
+			MarkdownDescription: "Point in time of the parent branch the branch was created from, in RFC 3339 format.",
+			Type:                types.StringType,
+		},
+		"logical_size": {
+			Computed:            true,
+			MarkdownDescription: "Logical size of the branch in bytes.",
+			Type:                types.Int64Type,
+		},
+		"current_state": {
+			Computed:            true,
+			MarkdownDescription: "Current state of the branch, for example `init` or `ready`.",
+			Type:                types.StringType,
+		},
+		"created_at": {
+			Computed:            true,
+			MarkdownDescription: "Time the branch was created.",
+			Type:                types.StringType,
+		},
+		"default": {
+			Computed:            true,
+			MarkdownDescription: "Whether the branch is the project's default branch.",
+			Type:                types.BoolType,
+		},
+	}
+}
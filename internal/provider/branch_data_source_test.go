@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"virtual-repetitions/terraform-provider-neon/src/neonApi"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNeonBranchDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNeonBranchDataSourceConfig(randomProjectName()),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.neon_branch.by_name", "id", "neon_branch.test", "id"),
+					resource.TestCheckResourceAttrPair("data.neon_branch.by_name", "parent_id", "neon_branch.test", "parent_id"),
+					resource.TestCheckResourceAttrPair("data.neon_branch.by_id", "name", "neon_branch.test", "name"),
+					resource.TestCheckResourceAttrPair("data.neon_branch.default", "id", "neon_project.test", "default_branch_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestNeonBranchDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testNeonApiClient(t)
+	d := &NeonBranchDataSource{client: client}
+
+	project := testCreateProject(t, client, "branch-lookup")
+	result, err := client.BranchCreate(ctx, project.Project.ID, neonApi.NeonBranchCreateData{
+		Branch: neonApi.NeonBranchCreateBranchAttributes{Name: "staging"},
+	}, neonApi.NeonApiClientOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lookups := map[string]neonBranchResourceModel{
+		"by ID":   testBranchDataSourceConfig(project.Project.ID, types.String{Value: result.Branch.ID}, types.String{Null: true}),
+		"by name": testBranchDataSourceConfig(project.Project.ID, types.String{Null: true}, types.String{Value: "staging"}),
+	}
+
+	for name, config := range lookups {
+		resp := testDataSourceRead(t, d, &config)
+		testFailOnDiagnostics(t, resp.Diagnostics)
+
+		var state neonBranchResourceModel
+		testFailOnDiagnostics(t, resp.State.Get(ctx, &state))
+
+		if state.ID.Value != result.Branch.ID || state.Name.Value != "staging" || state.ParentID.Null || state.Default.Value {
+			t.Errorf("Expected the staging branch to be found %s, got %+v", name, state)
+		}
+	}
+
+	config := testBranchDataSourceConfig(project.Project.ID, types.String{Null: true}, types.String{Value: "production"})
+	resp := testDataSourceRead(t, d, &config)
+
+	if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Branch not found" {
+		t.Errorf("Expected a missing branch name to be reported, got %v", resp.Diagnostics)
+	}
+}
+
+// testBranchDataSourceConfig returns a neon_branch data source config looking up the given ID or name.
+func testBranchDataSourceConfig(projectID string, id types.String, name types.String) neonBranchResourceModel {
+	return neonBranchResourceModel{
+		ID:              id,
+		ProjectID:       types.String{Value: projectID},
+		Name:            name,
+		ParentID:        types.String{Null: true},
+		ParentLsn:       types.String{Null: true},
+		ParentTimestamp: types.String{Null: true},
+		LogicalSize:     types.Int64{Null: true},
+		CurrentState:    types.String{Null: true},
+		CreatedAt:       types.String{Null: true},
+		Default:         types.Bool{Null: true},
+	}
+}
+
+func testAccNeonBranchDataSourceConfig(projectName string) string {
+	return fmt.Sprintf(`
+	provider "neon" { }
+	resource "neon_project" "test" {
+		name = "%s"
+		instance_handle = "scalable"
+		platform_id = "aws"
+		region_id = "aws-us-west-2"
+	}
+
+	resource "neon_branch" "test" {
+		project_id = neon_project.test.id
+		name = "staging"
+	}
+
+	data "neon_branch" "by_name" {
+		project_id = neon_project.test.id
+		name = neon_branch.test.name
+	}
+
+	data "neon_branch" "by_id" {
+		project_id = neon_project.test.id
+		id = neon_branch.test.id
+	}
+
+	data "neon_branch" "default" {
+		project_id = neon_project.test.id
+		name = "main"
+	}
+`, projectName)
+}
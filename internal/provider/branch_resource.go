@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 	"virtual-repetitions/terraform-provider-neon/src/neonApi"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -16,6 +17,8 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &NeonBranchResource{}
 var _ resource.ResourceWithImportState = &NeonBranchResource{}
+var _ resource.ResourceWithValidateConfig = &NeonBranchResource{}
+var _ resource.ResourceWithUpgradeState = &NeonBranchResource{}
 
 func NewNeonBranchResource() resource.Resource {
 	return &NeonBranchResource{}
@@ -29,7 +32,15 @@ type NeonBranchResource struct {
 // neonBranchResourceModel describes the resource data model.
 type neonBranchResourceModel struct {
 	ID              types.String `tfsdk:"id"`
-	ParentProjectID types.String `tfsdk:"parent_project_id"`
+	ProjectID       types.String `tfsdk:"project_id"`
+	Name            types.String `tfsdk:"name"`
+	ParentID        types.String `tfsdk:"parent_id"`
+	ParentLsn       types.String `tfsdk:"parent_lsn"`
+	ParentTimestamp types.String `tfsdk:"parent_timestamp"`
+	LogicalSize     types.Int64  `tfsdk:"logical_size"`
+	CurrentState    types.String `tfsdk:"current_state"`
+	CreatedAt       types.String `tfsdk:"created_at"`
+	Default         types.Bool   `tfsdk:"default"`
 }
 
 func (r *NeonBranchResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -41,6 +52,9 @@ func (r *NeonBranchResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Neon branch resource",
 
+		// Version 1 renamed parent_project_id to project_id.
+		Version: 1,
+
 		Attributes: map[string]tfsdk.Attribute{
 			"id": {
 				Computed:            true,
@@ -50,18 +64,112 @@ func (r *NeonBranchResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.
 					resource.UseStateForUnknown(),
 				},
 			},
-			"parent_project_id": {
-				Required: true,
-				Type:     types.StringType,
+			"project_id": {
+				Required:            true,
+				MarkdownDescription: "ID of the project the branch belongs to.",
+				Type:                types.StringType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					// When project_id changes, force recreation
+					resource.RequiresReplace(),
+				},
+			},
+			"name": {
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Branch name. Defaults to a name generated by Neon. Can be changed in place.",
+				Type:                types.StringType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"parent_id": {
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "ID of the branch to branch from. Defaults to the project's default branch.",
+				Type:                types.StringType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+					resource.RequiresReplace(),
+				},
+			},
+			"parent_lsn": {
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Log sequence number of the parent branch to branch from. Defaults to the head of the parent branch. Conflicts with `parent_timestamp`.",
+				Type:                types.StringType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+					resource.RequiresReplace(),
+				},
+			},
+			"parent_timestamp": {
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Point in time of the parent branch to branch from, in RFC 3339 format. Conflicts with `parent_lsn`.",
+				Type:                types.StringType,
 				PlanModifiers: []tfsdk.AttributePlanModifier{
-					// When parent_project_id changes, force recreation
+					resource.UseStateForUnknown(),
 					resource.RequiresReplace(),
 				},
 			},
+			"logical_size": {
+				Computed:            true,
+				MarkdownDescription: "Logical size of the branch in bytes.",
+				Type:                types.Int64Type,
+			},
+			"current_state": {
+				Computed:            true,
+				MarkdownDescription: "Current state of the branch, for example `init` or `ready`.",
+				Type:                types.StringType,
+			},
+			"created_at": {
+				Computed:            true,
+				MarkdownDescription: "Time the branch was created.",
+				Type:                types.StringType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"default": {
+				Computed:            true,
+				MarkdownDescription: "Whether the branch is the project's default branch.",
+				Type:                types.BoolType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+			},
 		},
 	}, nil
 }
 
+func (r *NeonBranchResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config neonBranchResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if isConfigured(config.ParentLsn) && isConfigured(config.ParentTimestamp) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("parent_timestamp"),
+			"Conflicting branch source",
+			"Only one of `parent_lsn` and `parent_timestamp` can be set.",
+		)
+	}
+
+	if isConfigured(config.ParentTimestamp) && !config.ParentTimestamp.Unknown {
+		if _, err := time.Parse(time.RFC3339, config.ParentTimestamp.Value); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("parent_timestamp"),
+				"Invalid parent timestamp",
+				fmt.Sprintf("Expected an RFC 3339 timestamp such as `2022-11-01T10:00:00Z`, got: %s", config.ParentTimestamp.Value),
+			)
+		}
+	}
+}
+
 func (r *NeonBranchResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -94,9 +202,18 @@ func (r *NeonBranchResource) Create(ctx context.Context, req resource.CreateRequ
 
 	tflog.Debug(ctx, "Creating Neon branch resource.")
 
-	result, err := r.client.BranchCreate(plan.ParentProjectID.Value, neonApi.NeonApiClientOptions{
-		NumRetries: 0,
-	})
+	result, err := r.client.BranchCreate(ctx, plan.ProjectID.Value, neonApi.NeonBranchCreateData{
+		Branch: neonApi.NeonBranchCreateBranchAttributes{
+			ParentID:        plan.ParentID.Value,
+			Name:            plan.Name.Value,
+			ParentLsn:       plan.ParentLsn.Value,
+			ParentTimestamp: plan.ParentTimestamp.Value,
+		},
+	}, neonApi.NeonApiClientOptions{})
+
+	if err != nil && result.Branch.ID != "" {
+		savePartiallyCreated(ctx, resp, newNeonBranchResourceModel(result.Branch, plan))
+	}
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -106,7 +223,7 @@ func (r *NeonBranchResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
-	plan.ID = types.String{Value: result.Project.ID}
+	plan = newNeonBranchResourceModel(result.Branch, plan)
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -126,9 +243,16 @@ func (r *NeonBranchResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	branch, err := r.client.ProjectRead(state.ID.Value, neonApi.NeonApiClientOptions{
-		NumRetries: 0,
-	})
+	branch, err := r.client.BranchRead(ctx, state.ProjectID.Value, state.ID.Value, neonApi.NeonApiClientOptions{})
+
+	if neonApi.IsNotFound(err) {
+		resp.Diagnostics.AddWarning(
+			"Branch not found",
+			fmt.Sprintf("Branch %s no longer exists in Neon and has been removed from the Terraform state. It will be created again on the next apply.", state.ID.Value),
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -138,26 +262,38 @@ func (r *NeonBranchResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	state = neonBranchResourceModel{
-		ID:              state.ID,
-		ParentProjectID: types.String{Value: branch.ParentID},
-	}
+	state = newNeonBranchResourceModel(branch, state)
 
 	// Save updated state into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
-// No updates allowed. See `parent_project_id` attribute.
+// Only the name is updated in place. The other configurable attributes force recreation.
 func (r *NeonBranchResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data neonBranchResourceModel
+	var plan neonBranchResourceModel
 
 	// Read Terraform plan data into the model
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	result, err := r.client.BranchUpdate(ctx, plan.ProjectID.Value, plan.ID.Value, neonApi.NeonBranchUpdateData{
+		Branch: neonApi.NeonBranchUpdateBranchAttributes{
+			Name: plan.Name.Value,
+		},
+	}, neonApi.NeonApiClientOptions{})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update branch, got error: %s", err))
+		return
+	}
+
+	plan = newNeonBranchResourceModel(result.Branch, plan)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
 func (r *NeonBranchResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -169,9 +305,7 @@ func (r *NeonBranchResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
-	err := r.client.ProjectDelete(state.ID.Value, neonApi.NeonApiClientOptions{
-		NumRetries: 0,
-	})
+	err := r.client.BranchDelete(ctx, state.ProjectID.Value, state.ID.Value, neonApi.NeonApiClientOptions{})
 
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete branch, got error: %s", err))
@@ -179,6 +313,107 @@ func (r *NeonBranchResource) Delete(ctx context.Context, req resource.DeleteRequ
 	}
 }
 
+// neonBranchResourceModelV0 describes the state of branches created before project_id was named so.
+type neonBranchResourceModelV0 struct {
+	ID              types.String `tfsdk:"id"`
+	ParentProjectID types.String `tfsdk:"parent_project_id"`
+}
+
+// UpgradeState moves parent_project_id of version 0 states to project_id. Read fills in the attributes
+// added since.
+func (r *NeonBranchResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &tfsdk.Schema{
+				Attributes: map[string]tfsdk.Attribute{
+					"id": {
+						Computed: true,
+						Type:     types.StringType,
+					},
+					"parent_project_id": {
+						Required: true,
+						Type:     types.StringType,
+					},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior neonBranchResourceModelV0
+
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				upgraded := neonBranchResourceModel{
+					ID:              prior.ID,
+					ProjectID:       prior.ParentProjectID,
+					Name:            types.String{Null: true},
+					ParentID:        types.String{Null: true},
+					ParentLsn:       types.String{Null: true},
+					ParentTimestamp: types.String{Null: true},
+					LogicalSize:     types.Int64{Null: true},
+					CurrentState:    types.String{Null: true},
+					CreatedAt:       types.String{Null: true},
+					Default:         types.Bool{Null: true},
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, &upgraded)...)
+			},
+		},
+	}
+}
+
+// ImportState imports a branch by an ID of the form `project_id/branch_id`. Read fills in the other attributes.
 func (r *NeonBranchResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	parts, err := parseImportID(req.ID, "project_id", "branch_id")
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), parts[0])...)
+}
+
+// newNeonBranchResourceModel builds the state of a branch from the API. A configured parent_timestamp is
+// kept as written when the API returns the same point in time in another format.
+func newNeonBranchResourceModel(branch neonApi.NeonBranch, prior neonBranchResourceModel) neonBranchResourceModel {
+	parentTimestamp := optionalString(branch.ParentTimestamp)
+	if isConfigured(prior.ParentTimestamp) && !prior.ParentTimestamp.Unknown && sameTimestamp(prior.ParentTimestamp.Value, branch.ParentTimestamp) {
+		parentTimestamp = prior.ParentTimestamp
+	}
+
+	return neonBranchResourceModel{
+		ID:              types.String{Value: branch.ID},
+		ProjectID:       types.String{Value: branch.ProjectID},
+		Name:            types.String{Value: branch.Name},
+		ParentID:        optionalString(branch.ParentID),
+		ParentLsn:       optionalString(branch.ParentLsn),
+		ParentTimestamp: parentTimestamp,
+		LogicalSize:     types.Int64{Value: branch.LogicalSize},
+		CurrentState:    types.String{Value: branch.CurrentState},
+		CreatedAt:       types.String{Value: branch.CreatedAt.Format(time.RFC3339)},
+		Default:         types.Bool{Value: branch.Default},
+	}
+}
+
+// optionalString converts an empty API value into a null attribute.
+func optionalString(value string) types.String {
+	if value == "" {
+		return types.String{Null: true}
+	}
+	return types.String{Value: value}
+}
+
+// isConfigured reports whether an attribute holds a value, or one that is not known yet.
+func isConfigured(value types.String) bool {
+	return !value.Null && (value.Unknown || value.Value != "")
+}
+
+func sameTimestamp(a string, b string) bool {
+	timeA, errA := time.Parse(time.RFC3339, a)
+	timeB, errB := time.Parse(time.RFC3339, b)
+	return errA == nil && errB == nil && timeA.Equal(timeB)
 }
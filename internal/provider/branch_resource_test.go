@@ -1,9 +1,14 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -18,16 +23,37 @@ func TestAccNeonBranchResourceIsCreated(t *testing.T) {
 
 			// Create and Read testing
 			{
-				Config: testAccNeonBranchResourceConfig("neon_project.test_parent_initial.id"),
+				Config: testAccNeonBranchResourceConfig("neon_project.test_parent_initial.id", "test-branch"),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					testAccNeonBranchID(&branchID),
 					resource.TestCheckResourceAttrSet("neon_branch.test", "id"),
+					resource.TestCheckResourceAttrSet("neon_branch.test", "parent_id"),
+					resource.TestCheckResourceAttrSet("neon_branch.test", "created_at"),
+					resource.TestCheckResourceAttr("neon_branch.test", "name", "test-branch"),
+					resource.TestCheckResourceAttr("neon_branch.test", "default", "false"),
 				),
 			},
 
-			// Tests that when parent_project_id changes the previous branch is destroyed and a new one exists
+			// Tests that renaming the branch updates it in place
 			{
-				Config: testAccNeonBranchResourceConfig("neon_project.test_parent_updated.id"),
+				Config: testAccNeonBranchResourceConfig("neon_project.test_parent_initial.id", "renamed-test-branch"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckBranchNotRecreated(&branchID),
+					resource.TestCheckResourceAttr("neon_branch.test", "name", "renamed-test-branch"),
+				),
+			},
+
+			// ImportState testing
+			{
+				ResourceName:      "neon_branch.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateID("neon_branch.test", "project_id", "id"),
+				ImportStateVerify: true,
+			},
+
+			// Tests that when project_id changes the previous branch is destroyed and a new one exists
+			{
+				Config: testAccNeonBranchResourceConfig("neon_project.test_parent_updated.id", "renamed-test-branch"),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					testAccCheckBranchRecreated(&branchID),
 					// Ideally we would check that the ID has changed but I couldn't figure out how to do that with the testing framework
@@ -38,7 +64,144 @@ func TestAccNeonBranchResourceIsCreated(t *testing.T) {
 	})
 }
 
-func testAccNeonBranchResourceConfig(parentProjectIDReference string) string {
+func TestAccNeonBranchResourceFromParentBranch(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNeonBranchResourceFromParentConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("neon_branch.child", "parent_id", "neon_branch.parent", "id"),
+					resource.TestCheckResourceAttrPair("neon_branch.child", "parent_lsn", "neon_branch.parent", "parent_lsn"),
+				),
+			},
+		},
+	})
+}
+
+// TestNeonBranchResourceReadRemovesMissingBranch verifies a branch deleted outside of Terraform is removed from state
+func TestNeonBranchResourceReadRemovesMissingBranch(t *testing.T) {
+	client, _ := testNeonApiClient(t)
+	r := &NeonBranchResource{client: client}
+
+	state := testResourceState(t, r, &neonBranchResourceModel{
+		ID:        types.String{Value: "deleted-branch"},
+		ProjectID: types.String{Value: "parent-project"},
+	})
+	resp := tfresource.ReadResponse{State: state}
+
+	r.Read(context.Background(), tfresource.ReadRequest{State: state}, &resp)
+
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("Expected a single warning, got %v", resp.Diagnostics)
+	}
+
+	if !resp.State.Raw.IsNull() {
+		t.Errorf("Expected branch to be removed from state")
+	}
+}
+
+// TestNeonBranchResourceImportState verifies branches are imported by a composite ID and fully read
+func TestNeonBranchResourceImportState(t *testing.T) {
+	client, server := testNeonApiClient(t)
+	r := &NeonBranchResource{client: client}
+
+	project := testCreateProject(t, client, "test-branches")
+	branch := server.Branches(project.Project.ID)[0]
+
+	resp, diags := testImportState(t, r, fmt.Sprintf("%s/%s", project.Project.ID, branch.ID))
+	testFailOnDiagnostics(t, diags)
+
+	var model neonBranchResourceModel
+	testFailOnDiagnostics(t, resp.State.Get(context.Background(), &model))
+
+	if model.ID.Value != branch.ID || model.ProjectID.Value != project.Project.ID || model.Name.Value != "main" || model.CreatedAt.Value == "" || !model.Default.Value {
+		t.Errorf("Expected imported branch to be fully read, got %+v", model)
+	}
+
+	if _, diags := testImportState(t, r, branch.ID); !diags.HasError() {
+		t.Errorf("Expected import ID without project to be rejected")
+	}
+}
+
+// TestNeonBranchResourceUpgradeStateV0 verifies branches stored with parent_project_id keep their project after upgrading
+func TestNeonBranchResourceUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	client, server := testNeonApiClient(t)
+	r := &NeonBranchResource{client: client}
+
+	project := testCreateProject(t, client, "test-branches")
+	branch := server.Branches(project.Project.ID)[0]
+
+	upgrader := r.UpgradeState(ctx)[0]
+	priorState := tfsdk.State{
+		Schema: *upgrader.PriorSchema,
+		Raw:    tftypes.NewValue(upgrader.PriorSchema.Type().TerraformType(ctx), nil),
+	}
+	testFailOnDiagnostics(t, priorState.Set(ctx, &neonBranchResourceModelV0{
+		ID:              types.String{Value: branch.ID},
+		ParentProjectID: types.String{Value: project.Project.ID},
+	}))
+
+	schema, diags := r.GetSchema(ctx)
+	testFailOnDiagnostics(t, diags)
+	if schema.Version != 1 {
+		t.Fatalf("Expected schema version 1, got %d", schema.Version)
+	}
+
+	upgradeResp := tfresource.UpgradeStateResponse{
+		State: tfsdk.State{
+			Schema: schema,
+			Raw:    tftypes.NewValue(schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	upgrader.StateUpgrader(ctx, tfresource.UpgradeStateRequest{State: &priorState}, &upgradeResp)
+	testFailOnDiagnostics(t, upgradeResp.Diagnostics)
+
+	readResp := tfresource.ReadResponse{State: upgradeResp.State}
+	r.Read(ctx, tfresource.ReadRequest{State: upgradeResp.State}, &readResp)
+	testFailOnDiagnostics(t, readResp.Diagnostics)
+
+	if readResp.State.Raw.IsNull() {
+		t.Fatal("Expected upgraded branch to stay in state")
+	}
+
+	var model neonBranchResourceModel
+	testFailOnDiagnostics(t, readResp.State.Get(ctx, &model))
+
+	if model.ID.Value != branch.ID || model.ProjectID.Value != project.Project.ID || model.Name.Value != "main" {
+		t.Errorf("Expected upgraded branch to be read from its project, got %+v", model)
+	}
+}
+
+// TestNeonBranchResourceValidateConfig verifies a branch can only be created from one of an LSN and a timestamp
+func TestNeonBranchResourceValidateConfig(t *testing.T) {
+	r := &NeonBranchResource{}
+
+	configs := map[string]neonBranchResourceModel{
+		"conflicting sources": {
+			ParentLsn:       types.String{Value: "0/1F00000"},
+			ParentTimestamp: types.String{Value: "2022-11-01T10:00:00Z"},
+		},
+		"invalid timestamp": {
+			ParentTimestamp: types.String{Value: "yesterday"},
+		},
+	}
+
+	for name, config := range configs {
+		state := testResourceState(t, r, &config)
+		resp := tfresource.ValidateConfigResponse{}
+
+		r.ValidateConfig(context.Background(), tfresource.ValidateConfigRequest{Config: tfsdk.Config{Schema: state.Schema, Raw: state.Raw}}, &resp)
+
+		if !resp.Diagnostics.HasError() {
+			t.Errorf("Expected %s to be rejected", name)
+		}
+	}
+}
+
+func testAccNeonBranchResourceConfig(projectIDReference string, branchName string) string {
 
 	config := fmt.Sprintf(`
 	provider "neon" { }
@@ -57,12 +220,38 @@ func testAccNeonBranchResourceConfig(parentProjectIDReference string) string {
 	}
 
 	resource "neon_branch" "test" {
-		parent_project_id = %s
+		project_id = %s
+		name = "%s"
 	}
-`, parentProjectIDReference)
+`, projectIDReference, branchName)
 	return config
 }
 
+func testAccNeonBranchResourceFromParentConfig() string {
+	return `
+	provider "neon" { }
+	resource "neon_project" "test" {
+		name = "test-branches-from-parent"
+		instance_handle = "scalable"
+		platform_id = "aws"
+		region_id = "aws-us-west-2"
+	}
+
+	resource "neon_branch" "parent" {
+		project_id = neon_project.test.id
+		name = "parent"
+	}
+
+	# The branch point of the parent is part of the parent's history, so it is a valid source for the child.
+	resource "neon_branch" "child" {
+		project_id = neon_project.test.id
+		name = "child"
+		parent_id = neon_branch.parent.id
+		parent_lsn = neon_branch.parent.parent_lsn
+	}
+`
+}
+
 func testAccNeonBranchID(branchID *string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 
@@ -77,6 +266,22 @@ func testAccNeonBranchID(branchID *string) resource.TestCheckFunc {
 	}
 }
 
+func testAccCheckBranchNotRecreated(previousBranchID *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+
+		updatedBranchID, err := testAccGetNeonBranchID("neon_branch.test", s)
+		if err != nil {
+			return err
+		}
+
+		if updatedBranchID != *previousBranchID {
+			return fmt.Errorf("Branch was recreated. resource: neon_branch.test")
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckBranchRecreated(previousBranchID *string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 
@@ -0,0 +1,200 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"virtual-repetitions/terraform-provider-neon/src/neonApi"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Orders in which the neon_branches data source sorts branches by creation time.
+const (
+	branchesSortOrderAsc  = "asc"
+	branchesSortOrderDesc = "desc"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &NeonBranchesDataSource{}
+var _ datasource.DataSourceWithConfigure = &NeonBranchesDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &NeonBranchesDataSource{}
+
+func NewNeonBranchesDataSource() datasource.DataSource {
+	return &NeonBranchesDataSource{}
+}
+
+// NeonBranchesDataSource defines the data source implementation.
+type NeonBranchesDataSource struct {
+	client neonApi.NeonApiClient
+}
+
+// neonBranchesDataSourceModel describes the data source data model.
+type neonBranchesDataSourceModel struct {
+	ID        types.String              `tfsdk:"id"`
+	ProjectID types.String              `tfsdk:"project_id"`
+	Name      types.String              `tfsdk:"name"`
+	NameRegex types.String              `tfsdk:"name_regex"`
+	ParentID  types.String              `tfsdk:"parent_id"`
+	SortOrder types.String              `tfsdk:"sort_order"`
+	Branches  []neonBranchResourceModel `tfsdk:"branches"`
+}
+
+func (d *NeonBranchesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_branches"
+}
+
+func (d *NeonBranchesDataSource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists the branches of a Neon project",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "ID of the project the branches belong to.",
+				Type:                types.StringType,
+			},
+			"project_id": {
+				Required:            true,
+				MarkdownDescription: "ID of the project to list the branches of.",
+				Type:                types.StringType,
+			},
+			"name": {
+				Optional:            true,
+				MarkdownDescription: "Only list the branch with this exact name.",
+				Type:                types.StringType,
+			},
+			"name_regex": {
+				Optional:            true,
+				MarkdownDescription: "Only list branches whose name matches this regular expression, in [RE2 syntax](https://github.com/google/re2/wiki/Syntax).",
+				Type:                types.StringType,
+			},
+			"parent_id": {
+				Optional:            true,
+				MarkdownDescription: "Only list branches created from the branch with this ID.",
+				Type:                types.StringType,
+			},
+			"sort_order": {
+				Optional:            true,
+				MarkdownDescription: "Order of the branches by creation time, either `asc` for oldest first or `desc` for newest first. Defaults to `asc`.",
+				Type:                types.StringType,
+			},
+			"branches": {
+				Computed:            true,
+				MarkdownDescription: "Matching branches.",
+				Attributes:          tfsdk.ListNestedAttributes(branchDataSourceAttributes()),
+			},
+		},
+	}, nil
+}
+
+func (d *NeonBranchesDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config neonBranchesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.NameRegex.Null && !config.NameRegex.Unknown {
+		if _, err := regexp.Compile(config.NameRegex.Value); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid name regular expression",
+				err.Error(),
+			)
+		}
+	}
+
+	if !config.SortOrder.Null && !config.SortOrder.Unknown && config.SortOrder.Value != branchesSortOrderAsc && config.SortOrder.Value != branchesSortOrderDesc {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("sort_order"),
+			"Invalid sort order",
+			fmt.Sprintf("Expected one of `%s` and `%s`, got: %s", branchesSortOrderAsc, branchesSortOrderDesc, config.SortOrder.Value),
+		)
+	}
+}
+
+func (d *NeonBranchesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(neonApi.NeonApiClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected neonApi.NeonApiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *NeonBranchesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state neonBranchesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !state.NameRegex.Null {
+		var err error
+		if nameRegex, err = regexp.Compile(state.NameRegex.Value); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid name regular expression", err.Error())
+			return
+		}
+	}
+
+	branches, err := d.client.BranchList(ctx, state.ProjectID.Value, neonApi.NeonApiClientOptions{})
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading branches",
+			"Could not list branches, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	descending := state.SortOrder.Value == branchesSortOrderDesc
+	sort.SliceStable(branches, func(i, j int) bool {
+		if descending {
+			return branches[i].CreatedAt.After(branches[j].CreatedAt)
+		}
+		return branches[i].CreatedAt.Before(branches[j].CreatedAt)
+	})
+
+	state.ID = state.ProjectID
+	state.Branches = []neonBranchResourceModel{}
+
+	for _, branch := range branches {
+		if !state.Name.Null && branch.Name != state.Name.Value {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(branch.Name) {
+			continue
+		}
+		if !state.ParentID.Null && branch.ParentID != state.ParentID.Value {
+			continue
+		}
+
+		state.Branches = append(state.Branches, newNeonBranchResourceModel(branch, neonBranchResourceModel{}))
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"virtual-repetitions/terraform-provider-neon/src/neonApi"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNeonBranchesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNeonBranchesDataSourceConfig(randomProjectName()),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.neon_branches.test", "branches.#", "1"),
+					resource.TestCheckResourceAttrPair("data.neon_branches.test", "branches.0.id", "neon_branch.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestNeonBranchesDataSourceFilters(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testNeonApiClient(t)
+	d := &NeonBranchesDataSource{client: client}
+
+	project := testCreateProject(t, client, "branch-list")
+	parentIDs := map[string]string{}
+
+	// Each branch is created from the branch created before it.
+	parentID := ""
+	for _, name := range []string{"preview-1", "preview-2", "staging"} {
+		result, err := client.BranchCreate(ctx, project.Project.ID, neonApi.NeonBranchCreateData{
+			Branch: neonApi.NeonBranchCreateBranchAttributes{Name: name, ParentID: parentID},
+		}, neonApi.NeonApiClientOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		parentID = result.Branch.ID
+		parentIDs[name] = parentID
+	}
+
+	filters := map[string]struct {
+		config   neonBranchesDataSourceModel
+		expected []string
+	}{
+		"none": {
+			config:   testBranchesDataSourceConfig(project.Project.ID),
+			expected: []string{"main", "preview-1", "preview-2", "staging"},
+		},
+		"name": {
+			config:   testBranchesDataSourceConfig(project.Project.ID, func(c *neonBranchesDataSourceModel) { c.Name = types.String{Value: "staging"} }),
+			expected: []string{"staging"},
+		},
+		"name regex newest first": {
+			config: testBranchesDataSourceConfig(project.Project.ID, func(c *neonBranchesDataSourceModel) {
+				c.NameRegex = types.String{Value: "^preview-[0-9]+$"}
+				c.SortOrder = types.String{Value: "desc"}
+			}),
+			expected: []string{"preview-2", "preview-1"},
+		},
+		"parent": {
+			config:   testBranchesDataSourceConfig(project.Project.ID, func(c *neonBranchesDataSourceModel) { c.ParentID = types.String{Value: parentIDs["preview-1"]} }),
+			expected: []string{"preview-2"},
+		},
+	}
+
+	for name, filter := range filters {
+		resp := testDataSourceRead(t, d, &filter.config)
+		testFailOnDiagnostics(t, resp.Diagnostics)
+
+		var state neonBranchesDataSourceModel
+		testFailOnDiagnostics(t, resp.State.Get(ctx, &state))
+
+		var names []string
+		for _, branch := range state.Branches {
+			names = append(names, branch.Name.Value)
+		}
+
+		if fmt.Sprint(names) != fmt.Sprint(filter.expected) {
+			t.Errorf("Expected filter %s to list %v, got %v", name, filter.expected, names)
+		}
+	}
+}
+
+func TestNeonBranchesDataSourceValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	d := &NeonBranchesDataSource{}
+
+	schema, diags := d.GetSchema(ctx)
+	testFailOnDiagnostics(t, diags)
+
+	configs := map[string]neonBranchesDataSourceModel{
+		"invalid regex":      testBranchesDataSourceConfig("project", func(c *neonBranchesDataSourceModel) { c.NameRegex = types.String{Value: "preview-("} }),
+		"invalid sort order": testBranchesDataSourceConfig("project", func(c *neonBranchesDataSourceModel) { c.SortOrder = types.String{Value: "newest"} }),
+	}
+
+	for name, config := range configs {
+		state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+		testFailOnDiagnostics(t, state.Set(ctx, &config))
+		resp := datasource.ValidateConfigResponse{}
+
+		d.ValidateConfig(ctx, datasource.ValidateConfigRequest{Config: tfsdk.Config{Schema: schema, Raw: state.Raw}}, &resp)
+
+		if !resp.Diagnostics.HasError() {
+			t.Errorf("Expected %s to be rejected", name)
+		}
+	}
+}
+
+// testBranchesDataSourceConfig returns a neon_branches data source config without filters, changed by the given options.
+func testBranchesDataSourceConfig(projectID string, options ...func(*neonBranchesDataSourceModel)) neonBranchesDataSourceModel {
+	config := neonBranchesDataSourceModel{
+		ID:        types.String{Null: true},
+		ProjectID: types.String{Value: projectID},
+		Name:      types.String{Null: true},
+		NameRegex: types.String{Null: true},
+		ParentID:  types.String{Null: true},
+		SortOrder: types.String{Null: true},
+	}
+	for _, option := range options {
+		option(&config)
+	}
+	return config
+}
+
+func testAccNeonBranchesDataSourceConfig(projectName string) string {
+	return fmt.Sprintf(`
+	provider "neon" { }
+	resource "neon_project" "test" {
+		name = "%s"
+		instance_handle = "scalable"
+		platform_id = "aws"
+		region_id = "aws-us-west-2"
+	}
+
+	resource "neon_branch" "test" {
+		project_id = neon_project.test.id
+		name = "preview-1"
+	}
+
+	data "neon_branches" "test" {
+		project_id = neon_project.test.id
+		name_regex = "^preview-"
+		depends_on = [neon_branch.test]
+	}
+`, projectName)
+}
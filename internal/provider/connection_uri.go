@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// Connection string formats rendered by the neon_connection_uri data source. Each format is also
+// rendered for the pooled host, under the format name with the `_pooled` suffix.
+const (
+	connectionFormatPsql         = "psql"
+	connectionFormatJdbc         = "jdbc"
+	connectionFormatPrisma       = "prisma"
+	connectionFormatDjango       = "django"
+	connectionFormatSQLAlchemy   = "sqlalchemy"
+	connectionFormatNodePostgres = "node_postgres"
+)
+
+// connectionParams holds what is needed to render a connection string.
+type connectionParams struct {
+	Host           string
+	RoleName       string
+	Password       string
+	DatabaseName   string
+	SslMode        string
+	ChannelBinding string
+	Pooled         bool
+}
+
+// connectionFormats renders the connection strings of every format, for the direct host and the
+// pooled host.
+func connectionFormats(params connectionParams) map[string]string {
+	formats := map[string]string{}
+
+	direct, pooled := params, params
+	direct.Pooled = false
+	pooled.Pooled = true
+	pooled.Host = pooledHost(params.Host)
+
+	for suffix, params := range map[string]connectionParams{"": direct, "_pooled": pooled} {
+		formats[connectionFormatPsql+suffix] = params.postgresURI("postgresql", true)
+		formats[connectionFormatJdbc+suffix] = params.jdbcURI()
+		formats[connectionFormatPrisma+suffix] = params.prismaURI()
+		formats[connectionFormatDjango+suffix] = params.djangoSettings()
+		formats[connectionFormatSQLAlchemy+suffix] = params.postgresURI("postgresql+psycopg2", true)
+		formats[connectionFormatNodePostgres+suffix] = params.postgresURI("postgresql", false)
+	}
+
+	return formats
+}
+
+// pooledHost returns the host of the connection pooler of an endpoint, which carries the `-pooler`
+// suffix on the endpoint ID, as in `ep-cool-darkness-123456-pooler.us-east-2.aws.neon.tech`.
+func pooledHost(host string) string {
+	endpointID, domain, found := strings.Cut(host, ".")
+	if !found || strings.HasSuffix(endpointID, "-pooler") {
+		return host
+	}
+	return endpointID + "-pooler." + domain
+}
+
+// postgresURI renders a libpq style URI. Clients that do not understand channel_binding leave it out.
+func (params connectionParams) postgresURI(scheme string, channelBinding bool) string {
+	query := url.Values{}
+	query.Set("sslmode", params.SslMode)
+	if channelBinding && params.ChannelBinding != "" {
+		query.Set("channel_binding", params.ChannelBinding)
+	}
+
+	uri := url.URL{
+		Scheme:   scheme,
+		User:     url.UserPassword(params.RoleName, params.Password),
+		Host:     params.Host,
+		Path:     "/" + params.DatabaseName,
+		RawQuery: query.Encode(),
+	}
+	return uri.String()
+}
+
+// jdbcURI renders a URI for the PostgreSQL JDBC driver, which takes the credentials as parameters.
+func (params connectionParams) jdbcURI() string {
+	query := url.Values{}
+	query.Set("user", params.RoleName)
+	query.Set("password", params.Password)
+	query.Set("sslmode", params.SslMode)
+	if params.ChannelBinding != "" {
+		query.Set("channelBinding", params.ChannelBinding)
+	}
+
+	return "jdbc:postgresql://" + params.Host + "/" + url.PathEscape(params.DatabaseName) + "?" + query.Encode()
+}
+
+// prismaURI renders a URI for Prisma, which must be told when it connects through PgBouncer.
+func (params connectionParams) prismaURI() string {
+	uri := params.postgresURI("postgresql", false)
+	if params.Pooled {
+		uri += "&pgbouncer=true"
+	}
+	return uri
+}
+
+// djangoSettings renders the `DATABASES` entry of Django settings as JSON.
+func (params connectionParams) djangoSettings() string {
+	options := map[string]string{"sslmode": params.SslMode}
+	if params.ChannelBinding != "" {
+		options["channel_binding"] = params.ChannelBinding
+	}
+
+	settings, _ := json.Marshal(struct {
+		Engine   string            `json:"ENGINE"`
+		Name     string            `json:"NAME"`
+		User     string            `json:"USER"`
+		Password string            `json:"PASSWORD"`
+		Host     string            `json:"HOST"`
+		Port     string            `json:"PORT"`
+		Options  map[string]string `json:"OPTIONS"`
+	}{
+		Engine:   "django.db.backends.postgresql",
+		Name:     params.DatabaseName,
+		User:     params.RoleName,
+		Password: params.Password,
+		Host:     params.Host,
+		Port:     "5432",
+		Options:  options,
+	})
+
+	return string(settings)
+}
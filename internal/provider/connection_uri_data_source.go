@@ -0,0 +1,291 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"virtual-repetitions/terraform-provider-neon/src/neonApi"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Default and accepted values of the sslmode and channel_binding connection parameters.
+const defaultSslMode = "require"
+
+var sslModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+var channelBindings = []string{"disable", "prefer", "require"}
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &NeonConnectionURIDataSource{}
+var _ datasource.DataSourceWithConfigure = &NeonConnectionURIDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &NeonConnectionURIDataSource{}
+
+func NewNeonConnectionURIDataSource() datasource.DataSource {
+	return &NeonConnectionURIDataSource{}
+}
+
+// NeonConnectionURIDataSource defines the data source implementation.
+type NeonConnectionURIDataSource struct {
+	client neonApi.NeonApiClient
+}
+
+// neonConnectionURIDataSourceModel describes the data source data model.
+type neonConnectionURIDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	ProjectID      types.String `tfsdk:"project_id"`
+	BranchID       types.String `tfsdk:"branch_id"`
+	EndpointID     types.String `tfsdk:"endpoint_id"`
+	RoleName       types.String `tfsdk:"role_name"`
+	DatabaseName   types.String `tfsdk:"database_name"`
+	SslMode        types.String `tfsdk:"sslmode"`
+	ChannelBinding types.String `tfsdk:"channel_binding"`
+	Host           types.String `tfsdk:"host"`
+	PooledHost     types.String `tfsdk:"pooled_host"`
+	Formats        types.Map    `tfsdk:"formats"`
+}
+
+func (d *NeonConnectionURIDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_connection_uri"
+}
+
+func (d *NeonConnectionURIDataSource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Renders the connection strings of a Neon database for common clients",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "ID of the form `project_id/branch_id/endpoint_id/role_name/database_name`.",
+				Type:                types.StringType,
+			},
+			"project_id": {
+				Required:            true,
+				MarkdownDescription: "ID of the project to connect to.",
+				Type:                types.StringType,
+			},
+			"branch_id": {
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "ID of the branch to connect to. Defaults to the branch of `endpoint_id`, or the project's default branch.",
+				Type:                types.StringType,
+			},
+			"endpoint_id": {
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "ID of the endpoint to connect through. Defaults to the `read_write` endpoint of the branch.",
+				Type:                types.StringType,
+			},
+			"role_name": {
+				Required:            true,
+				MarkdownDescription: "Name of the role to connect as. Its password is read from Neon.",
+				Type:                types.StringType,
+			},
+			"database_name": {
+				Required:            true,
+				MarkdownDescription: "Name of the database to connect to.",
+				Type:                types.StringType,
+			},
+			"sslmode": {
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Value of the `sslmode` parameter, one of `disable`, `allow`, `prefer`, `require`, `verify-ca` and `verify-full`. Defaults to `require`.",
+				Type:                types.StringType,
+			},
+			"channel_binding": {
+				Optional:            true,
+				MarkdownDescription: "Value of the `channel_binding` parameter, one of `disable`, `prefer` and `require`. Left out by default. Prisma and node-postgres connection strings never include it.",
+				Type:                types.StringType,
+			},
+			"host": {
+				Computed:            true,
+				MarkdownDescription: "Host of the endpoint.",
+				Type:                types.StringType,
+			},
+			"pooled_host": {
+				Computed:            true,
+				MarkdownDescription: "Host of the connection pooler of the endpoint.",
+				Type:                types.StringType,
+			},
+			"formats": {
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Connection strings by client: `psql`, `jdbc`, `prisma`, `django` (JSON of a `DATABASES` entry), `sqlalchemy` and `node_postgres`. Each is also rendered for the pooled host under the name with the `_pooled` suffix, such as `psql_pooled`.",
+				Type:                types.MapType{ElemType: types.StringType},
+			},
+		},
+	}, nil
+}
+
+func (d *NeonConnectionURIDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config neonConnectionURIDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateOneOf(path.Root("sslmode"), config.SslMode, sslModes, &resp.Diagnostics)
+	validateOneOf(path.Root("channel_binding"), config.ChannelBinding, channelBindings, &resp.Diagnostics)
+}
+
+func (d *NeonConnectionURIDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(neonApi.NeonApiClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected neonApi.NeonApiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *NeonConnectionURIDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state neonConnectionURIDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID := state.ProjectID.Value
+
+	endpoint, err := d.findEndpoint(ctx, projectID, state.BranchID, state.EndpointID)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading connection URI",
+			"Could not find the endpoint to connect through: "+err.Error(),
+		)
+		return
+	}
+
+	_, err = d.client.DatabaseRead(ctx, projectID, endpoint.BranchID, state.DatabaseName.Value, neonApi.NeonApiClientOptions{})
+
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("database_name"),
+			"Error reading connection URI",
+			fmt.Sprintf("Could not read database %s of branch %s: %s", state.DatabaseName.Value, endpoint.BranchID, err),
+		)
+		return
+	}
+
+	password, err := d.client.RoleRevealPassword(ctx, projectID, endpoint.BranchID, state.RoleName.Value, neonApi.NeonApiClientOptions{})
+
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("role_name"),
+			"Error reading connection URI",
+			fmt.Sprintf("Could not read the password of role %s of branch %s: %s", state.RoleName.Value, endpoint.BranchID, err),
+		)
+		return
+	}
+
+	if state.SslMode.Null || state.SslMode.Value == "" {
+		state.SslMode = types.String{Value: defaultSslMode}
+	}
+
+	formats := connectionFormats(connectionParams{
+		Host:           endpoint.Host,
+		RoleName:       state.RoleName.Value,
+		Password:       password,
+		DatabaseName:   state.DatabaseName.Value,
+		SslMode:        state.SslMode.Value,
+		ChannelBinding: state.ChannelBinding.Value,
+	})
+
+	state.ID = types.String{Value: fmt.Sprintf("%s/%s/%s/%s/%s", projectID, endpoint.BranchID, endpoint.ID, state.RoleName.Value, state.DatabaseName.Value)}
+	state.BranchID = types.String{Value: endpoint.BranchID}
+	state.EndpointID = types.String{Value: endpoint.ID}
+	state.Host = types.String{Value: endpoint.Host}
+	state.PooledHost = types.String{Value: pooledHost(endpoint.Host)}
+	state.Formats = types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{}}
+	for name, format := range formats {
+		state.Formats.Elems[name] = types.String{Value: format}
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// findEndpoint returns the configured endpoint, or the read_write endpoint of the configured branch,
+// which defaults to the project's default branch.
+func (d *NeonConnectionURIDataSource) findEndpoint(ctx context.Context, projectID string, branchID types.String, endpointID types.String) (neonApi.NeonEndpoint, error) {
+	if !endpointID.Null {
+		endpoint, err := d.client.EndpointRead(ctx, projectID, endpointID.Value, neonApi.NeonApiClientOptions{})
+
+		if err == nil && !branchID.Null && endpoint.BranchID != branchID.Value {
+			err = fmt.Errorf("endpoint %s serves branch %s, not %s", endpoint.ID, endpoint.BranchID, branchID.Value)
+		}
+
+		return endpoint, err
+	}
+
+	if branchID.Null {
+		branches, err := d.client.BranchList(ctx, projectID, neonApi.NeonApiClientOptions{})
+
+		if err != nil {
+			return neonApi.NeonEndpoint{}, err
+		}
+
+		for _, branch := range branches {
+			if branch.Default {
+				branchID = types.String{Value: branch.ID}
+			}
+		}
+
+		if branchID.Null {
+			return neonApi.NeonEndpoint{}, fmt.Errorf("project %s has no default branch", projectID)
+		}
+	}
+
+	endpoints, err := d.client.EndpointList(ctx, projectID, neonApi.NeonApiClientOptions{})
+
+	if err != nil {
+		return neonApi.NeonEndpoint{}, err
+	}
+
+	for _, endpoint := range endpoints {
+		if endpoint.BranchID == branchID.Value && endpoint.Type == neonApi.NeonEndpointTypeReadWrite {
+			return endpoint, nil
+		}
+	}
+
+	return neonApi.NeonEndpoint{}, fmt.Errorf("branch %s has no %s endpoint, set `endpoint_id` to connect through another one", branchID.Value, neonApi.NeonEndpointTypeReadWrite)
+}
+
+// validateOneOf adds an error to diags when a known value is not one of the allowed values.
+func validateOneOf(attributePath path.Path, value types.String, allowed []string, diags *diag.Diagnostics) {
+	if value.Null || value.Unknown {
+		return
+	}
+
+	for _, allowedValue := range allowed {
+		if value.Value == allowedValue {
+			return
+		}
+	}
+
+	diags.AddAttributeError(
+		attributePath,
+		"Invalid value",
+		fmt.Sprintf("Expected one of `%s`, got: %s", strings.Join(allowed, "`, `"), value.Value),
+	)
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNeonConnectionURIDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNeonConnectionURIDataSourceConfig(randomProjectName()),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.neon_connection_uri.test", "branch_id", "neon_project.test", "default_branch_id"),
+					resource.TestCheckResourceAttrPair("data.neon_connection_uri.test", "host", "neon_project.test", "default_endpoint_host"),
+					resource.TestCheckResourceAttr("data.neon_connection_uri.test", "sslmode", "require"),
+					resource.TestCheckResourceAttrSet("data.neon_connection_uri.test", "formats.jdbc_pooled"),
+				),
+			},
+		},
+	})
+}
+
+func TestNeonConnectionURIDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testNeonApiClient(t)
+	d := &NeonConnectionURIDataSource{client: client}
+
+	project := testCreateProject(t, client, "connection-uri")
+	role := project.Response.Roles[0]
+
+	config := testConnectionURIDataSourceConfig(project.Project.ID, role.Name, project.Response.Databases[0].Name)
+	config.ChannelBinding = types.String{Value: "require"}
+	resp := testDataSourceRead(t, d, &config)
+	testFailOnDiagnostics(t, resp.Diagnostics)
+
+	var state neonConnectionURIDataSourceModel
+	testFailOnDiagnostics(t, resp.State.Get(ctx, &state))
+
+	if state.BranchID.Null || state.EndpointID.Null || !strings.Contains(state.PooledHost.Value, "-pooler.") {
+		t.Errorf("Expected the default branch and endpoint to be used, got %+v", state)
+	}
+
+	psql := state.Formats.Elems["psql"].(types.String).Value
+	expected := fmt.Sprintf("postgresql://%s:%s@%s/neondb?channel_binding=require&sslmode=require", role.Name, role.Password, state.Host.Value)
+	if psql != expected {
+		t.Errorf("Expected psql connection string %s, got %s", expected, psql)
+	}
+
+	config = testConnectionURIDataSourceConfig(project.Project.ID, role.Name, "missing")
+	resp = testDataSourceRead(t, d, &config)
+
+	if !resp.Diagnostics.HasError() {
+		t.Errorf("Expected a missing database to be reported")
+	}
+}
+
+func TestNeonConnectionURIDataSourceValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	d := &NeonConnectionURIDataSource{}
+
+	schema, diags := d.GetSchema(ctx)
+	testFailOnDiagnostics(t, diags)
+
+	invalidSslMode := testConnectionURIDataSourceConfig("project", "role", "database")
+	invalidSslMode.SslMode = types.String{Value: "required"}
+	invalidChannelBinding := testConnectionURIDataSourceConfig("project", "role", "database")
+	invalidChannelBinding.ChannelBinding = types.String{Value: "true"}
+
+	for name, config := range map[string]neonConnectionURIDataSourceModel{"sslmode": invalidSslMode, "channel_binding": invalidChannelBinding} {
+		state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+		testFailOnDiagnostics(t, state.Set(ctx, &config))
+		resp := datasource.ValidateConfigResponse{}
+
+		d.ValidateConfig(ctx, datasource.ValidateConfigRequest{Config: tfsdk.Config{Schema: schema, Raw: state.Raw}}, &resp)
+
+		if !resp.Diagnostics.HasError() {
+			t.Errorf("Expected an invalid %s to be rejected", name)
+		}
+	}
+}
+
+// testConnectionURIDataSourceConfig returns a neon_connection_uri data source config for the default branch and endpoint.
+func testConnectionURIDataSourceConfig(projectID string, roleName string, databaseName string) neonConnectionURIDataSourceModel {
+	return neonConnectionURIDataSourceModel{
+		ID:             types.String{Null: true},
+		ProjectID:      types.String{Value: projectID},
+		BranchID:       types.String{Null: true},
+		EndpointID:     types.String{Null: true},
+		RoleName:       types.String{Value: roleName},
+		DatabaseName:   types.String{Value: databaseName},
+		SslMode:        types.String{Null: true},
+		ChannelBinding: types.String{Null: true},
+		Host:           types.String{Null: true},
+		PooledHost:     types.String{Null: true},
+		Formats:        types.Map{ElemType: types.StringType, Null: true},
+	}
+}
+
+func testAccNeonConnectionURIDataSourceConfig(projectName string) string {
+	return fmt.Sprintf(`
+	provider "neon" { }
+	resource "neon_project" "test" {
+		name = "%s"
+		instance_handle = "scalable"
+		platform_id = "aws"
+		region_id = "aws-us-west-2"
+	}
+
+	data "neon_connection_uri" "test" {
+		project_id = neon_project.test.id
+		role_name = neon_project.test.default_role_name
+		database_name = neon_project.test.default_database_name
+	}
+`, projectName)
+}
@@ -0,0 +1,14 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// savePartiallyCreated saves a resource that Neon created but whose operations did not finish, before Create
+// reports the error. Terraform then tracks the resource, marks it as tainted and replaces it on the next apply,
+// instead of losing it.
+func savePartiallyCreated(ctx context.Context, resp *resource.CreateResponse, model any) {
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+}
@@ -0,0 +1,248 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"virtual-repetitions/terraform-provider-neon/src/neonApi"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &NeonDatabaseResource{}
+var _ resource.ResourceWithImportState = &NeonDatabaseResource{}
+
+func NewNeonDatabaseResource() resource.Resource {
+	return &NeonDatabaseResource{}
+}
+
+// NeonDatabaseResource defines the resource implementation.
+type NeonDatabaseResource struct {
+	client neonApi.NeonApiClient
+}
+
+// neonDatabaseResourceModel describes the resource data model.
+type neonDatabaseResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	ProjectID types.String `tfsdk:"project_id"`
+	BranchID  types.String `tfsdk:"branch_id"`
+	Name      types.String `tfsdk:"name"`
+	OwnerName types.String `tfsdk:"owner_name"`
+}
+
+func (r *NeonDatabaseResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_database"
+}
+
+func (r *NeonDatabaseResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Neon database resource",
+
+		Attributes: map[string]tfsdk.Attribute{
+			// The ID contains the name, so it changes when the database is renamed.
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "Database ID of the form `project_id/branch_id/name`",
+				Type:                types.StringType,
+			},
+			"project_id": {
+				Required:            true,
+				MarkdownDescription: "ID of the project the database belongs to.",
+				Type:                types.StringType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.RequiresReplace(),
+				},
+			},
+			"branch_id": {
+				Required:            true,
+				MarkdownDescription: "ID of the branch the database belongs to.",
+				Type:                types.StringType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.RequiresReplace(),
+				},
+			},
+			"name": {
+				Required:            true,
+				MarkdownDescription: "Database name. Can be changed in place.",
+				Type:                types.StringType,
+			},
+			"owner_name": {
+				Required:            true,
+				MarkdownDescription: "Name of the role owning the database. Can be changed in place.",
+				Type:                types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (r *NeonDatabaseResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(neonApi.NeonApiClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected neonApi.NeonApiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *NeonDatabaseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan neonDatabaseResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating Neon database resource.")
+
+	result, err := r.client.DatabaseCreate(ctx, plan.ProjectID.Value, plan.BranchID.Value, neonApi.NeonDatabaseCreateData{
+		Database: neonApi.NeonDatabaseCreateDatabaseAttributes{
+			Name:      plan.Name.Value,
+			OwnerName: plan.OwnerName.Value,
+		},
+	}, neonApi.NeonApiClientOptions{})
+
+	if err != nil && result.Database.Name != "" {
+		savePartiallyCreated(ctx, resp, newNeonDatabaseResourceModel(plan.ProjectID.Value, result.Database))
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating database",
+			"Could not create database, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan = newNeonDatabaseResourceModel(plan.ProjectID.Value, result.Database)
+
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *NeonDatabaseResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state neonDatabaseResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	database, err := r.client.DatabaseRead(ctx, state.ProjectID.Value, state.BranchID.Value, state.Name.Value, neonApi.NeonApiClientOptions{})
+
+	if neonApi.IsNotFound(err) {
+		resp.Diagnostics.AddWarning(
+			"Database not found",
+			fmt.Sprintf("Database %s no longer exists in Neon and has been removed from the Terraform state. It will be created again on the next apply.", state.ID.Value),
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading database",
+			"Could not read database, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	state = newNeonDatabaseResourceModel(state.ProjectID.Value, database)
+
+	// Save updated state into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *NeonDatabaseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state neonDatabaseResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The database is addressed by its current name, which may be about to change.
+	result, err := r.client.DatabaseUpdate(ctx, state.ProjectID.Value, state.BranchID.Value, state.Name.Value, neonApi.NeonDatabaseUpdateData{
+		Database: neonApi.NeonDatabaseUpdateDatabaseAttributes{
+			Name:      plan.Name.Value,
+			OwnerName: plan.OwnerName.Value,
+		},
+	}, neonApi.NeonApiClientOptions{})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update database, got error: %s", err))
+		return
+	}
+
+	plan = newNeonDatabaseResourceModel(plan.ProjectID.Value, result.Database)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *NeonDatabaseResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state neonDatabaseResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DatabaseDelete(ctx, state.ProjectID.Value, state.BranchID.Value, state.Name.Value, neonApi.NeonApiClientOptions{})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete database, got error: %s", err))
+		return
+	}
+}
+
+// ImportState imports a database by an ID of the form `project_id/branch_id/name`.
+func (r *NeonDatabaseResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, err := parseImportID(req.ID, "project_id", "branch_id", "name")
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("branch_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), parts[2])...)
+}
+
+func newNeonDatabaseResourceModel(projectID string, database neonApi.NeonDatabase) neonDatabaseResourceModel {
+	return neonDatabaseResourceModel{
+		ID:        types.String{Value: fmt.Sprintf("%s/%s/%s", projectID, database.BranchID, database.Name)},
+		ProjectID: types.String{Value: projectID},
+		BranchID:  types.String{Value: database.BranchID},
+		Name:      types.String{Value: database.Name},
+		OwnerName: types.String{Value: database.OwnerName},
+	}
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNeonDatabaseResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccNeonDatabaseResourceConfig("app", "neon_role.owner.name"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_database.test", "name", "app"),
+					resource.TestCheckResourceAttr("neon_database.test", "owner_name", "app_owner"),
+				),
+			},
+
+			// Rename and owner change in place
+			{
+				Config: testAccNeonDatabaseResourceConfig("renamed_app", "neon_role.other_owner.name"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_database.test", "name", "renamed_app"),
+					resource.TestCheckResourceAttr("neon_database.test", "owner_name", "other_owner"),
+				),
+			},
+
+			// ImportState testing
+			{
+				ResourceName:      "neon_database.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// TestNeonDatabaseResourceImportState verifies databases are imported by a composite ID
+func TestNeonDatabaseResourceImportState(t *testing.T) {
+	r := &NeonDatabaseResource{}
+
+	state := testResourceState(t, r, &neonDatabaseResourceModel{})
+	state.Raw = tftypes.NewValue(state.Schema.Type().TerraformType(context.Background()), nil)
+
+	resp := tfresource.ImportStateResponse{State: state}
+	r.ImportState(context.Background(), tfresource.ImportStateRequest{ID: "project-1/br-1/app"}, &resp)
+
+	var model neonDatabaseResourceModel
+	testFailOnDiagnostics(t, resp.Diagnostics)
+	testFailOnDiagnostics(t, resp.State.Get(context.Background(), &model))
+
+	if model.ProjectID.Value != "project-1" || model.BranchID.Value != "br-1" || model.Name.Value != "app" {
+		t.Errorf("Expected import ID to be split into its parts, got %+v", model)
+	}
+
+	resp = tfresource.ImportStateResponse{State: state}
+	r.ImportState(context.Background(), tfresource.ImportStateRequest{ID: "project-1/app"}, &resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Errorf("Expected incomplete import ID to be rejected")
+	}
+}
+
+func testAccNeonDatabaseResourceConfig(name string, ownerReference string) string {
+	return fmt.Sprintf(`
+	provider "neon" { }
+	resource "neon_project" "test" {
+		name = "test-databases"
+		instance_handle = "scalable"
+		platform_id = "aws"
+		region_id = "aws-us-west-2"
+	}
+
+	resource "neon_branch" "test" {
+		project_id = neon_project.test.id
+		name = "test-databases"
+	}
+
+	resource "neon_role" "owner" {
+		project_id = neon_project.test.id
+		branch_id = neon_branch.test.id
+		name = "app_owner"
+	}
+
+	resource "neon_role" "other_owner" {
+		project_id = neon_project.test.id
+		branch_id = neon_branch.test.id
+		name = "other_owner"
+	}
+
+	resource "neon_database" "test" {
+		project_id = neon_project.test.id
+		branch_id = neon_branch.test.id
+		name = "%s"
+		owner_name = %s
+	}
+`, name, ownerReference)
+}
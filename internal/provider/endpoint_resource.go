@@ -0,0 +1,404 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"virtual-repetitions/terraform-provider-neon/src/neonApi"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &NeonEndpointResource{}
+var _ resource.ResourceWithImportState = &NeonEndpointResource{}
+var _ resource.ResourceWithValidateConfig = &NeonEndpointResource{}
+var _ resource.ResourceWithModifyPlan = &NeonEndpointResource{}
+
+func NewNeonEndpointResource() resource.Resource {
+	return &NeonEndpointResource{}
+}
+
+// NeonEndpointResource defines the resource implementation.
+type NeonEndpointResource struct {
+	client neonApi.NeonApiClient
+}
+
+// neonEndpointResourceModel describes the resource data model.
+type neonEndpointResourceModel struct {
+	ID                    types.String  `tfsdk:"id"`
+	ProjectID             types.String  `tfsdk:"project_id"`
+	BranchID              types.String  `tfsdk:"branch_id"`
+	Type                  types.String  `tfsdk:"type"`
+	RegionID              types.String  `tfsdk:"region_id"`
+	AutoscalingLimitMinCu types.Float64 `tfsdk:"autoscaling_limit_min_cu"`
+	AutoscalingLimitMaxCu types.Float64 `tfsdk:"autoscaling_limit_max_cu"`
+	SuspendTimeoutSeconds types.Int64   `tfsdk:"suspend_timeout_seconds"`
+	PoolerEnabled         types.Bool    `tfsdk:"pooler_enabled"`
+	PoolerMode            types.String  `tfsdk:"pooler_mode"`
+	Host                  types.String  `tfsdk:"host"`
+	CurrentState          types.String  `tfsdk:"current_state"`
+}
+
+func (r *NeonEndpointResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_endpoint"
+}
+
+func (r *NeonEndpointResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Neon compute endpoint resource",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "Endpoint ID",
+				Type:                types.StringType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"project_id": {
+				Required:            true,
+				MarkdownDescription: "ID of the project the endpoint belongs to.",
+				Type:                types.StringType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.RequiresReplace(),
+				},
+			},
+			"branch_id": {
+				Required:            true,
+				MarkdownDescription: "ID of the branch the endpoint serves. Can be changed in place.",
+				Type:                types.StringType,
+			},
+			"type": {
+				Required:            true,
+				MarkdownDescription: "Endpoint type, either `read_write` or `read_only`. A branch has at most one `read_write` endpoint.",
+				Type:                types.StringType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.RequiresReplace(),
+				},
+			},
+			"region_id": {
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Region of the endpoint, for example `aws-us-west-2`. The platform prefix may be left out, as in `us-west-2`. Defaults to the region of the project.",
+				Type:                types.StringType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+					regionIDKeepsEquivalentState(),
+					regionIDRequiresReplace(),
+				},
+			},
+			"autoscaling_limit_min_cu": {
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Minimum number of compute units the endpoint scales down to.",
+				Type:                types.Float64Type,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"autoscaling_limit_max_cu": {
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Maximum number of compute units the endpoint scales up to.",
+				Type:                types.Float64Type,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"suspend_timeout_seconds": {
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Seconds of inactivity after which the endpoint is suspended. `0` uses the Neon default and `-1` never suspends.",
+				Type:                types.Int64Type,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"pooler_enabled": {
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Whether connections go through the connection pooler.",
+				Type:                types.BoolType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"pooler_mode": {
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Connection pooler mode. Neon supports `transaction`.",
+				Type:                types.StringType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"host": {
+				Computed:            true,
+				MarkdownDescription: "Hostname to connect to the endpoint.",
+				Type:                types.StringType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"current_state": {
+				Computed:            true,
+				MarkdownDescription: "Current state of the endpoint, for example `active` or `idle`.",
+				Type:                types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (r *NeonEndpointResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config neonEndpointResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Type.Null && !config.Type.Unknown && config.Type.Value != neonApi.NeonEndpointTypeReadWrite && config.Type.Value != neonApi.NeonEndpointTypeReadOnly {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("type"),
+			"Invalid endpoint type",
+			fmt.Sprintf("Expected one of `%s` and `%s`, got: %s", neonApi.NeonEndpointTypeReadWrite, neonApi.NeonEndpointTypeReadOnly, config.Type.Value),
+		)
+	}
+
+	minCu, maxCu := config.AutoscalingLimitMinCu, config.AutoscalingLimitMaxCu
+	if !minCu.Null && !minCu.Unknown && !maxCu.Null && !maxCu.Unknown && minCu.Value > maxCu.Value {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("autoscaling_limit_min_cu"),
+			"Invalid autoscaling limits",
+			fmt.Sprintf("`autoscaling_limit_min_cu` (%g) must not be greater than `autoscaling_limit_max_cu` (%g).", minCu.Value, maxCu.Value),
+		)
+	}
+}
+
+// ModifyPlan rejects regions missing from the region catalog when an endpoint is created or moved to another region.
+func (r *NeonEndpointResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state neonEndpointResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.RegionID.Null || !neonApi.EquivalentRegionIDs(state.RegionID.Value, plan.RegionID.Value) {
+		validateRegionID(ctx, r.client, path.Root("region_id"), plan.RegionID, &resp.Diagnostics)
+	}
+}
+
+func (r *NeonEndpointResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(neonApi.NeonApiClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected neonApi.NeonApiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *NeonEndpointResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan neonEndpointResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating Neon endpoint resource.")
+
+	result, err := r.client.EndpointCreate(ctx, plan.ProjectID.Value, neonApi.NeonEndpointCreateData{
+		Endpoint: neonApi.NeonEndpointCreateEndpointAttributes{
+			BranchID:              plan.BranchID.Value,
+			Type:                  plan.Type.Value,
+			RegionID:              endpointRegionID(plan.RegionID),
+			AutoscalingLimitMinCu: plan.AutoscalingLimitMinCu.Value,
+			AutoscalingLimitMaxCu: plan.AutoscalingLimitMaxCu.Value,
+			SuspendTimeoutSeconds: plan.SuspendTimeoutSeconds.Value,
+			PoolerEnabled:         plan.PoolerEnabled.Value,
+			PoolerMode:            plan.PoolerMode.Value,
+		},
+	}, neonApi.NeonApiClientOptions{})
+
+	if err != nil && result.Endpoint.ID != "" {
+		savePartiallyCreated(ctx, resp, newNeonEndpointResourceModel(result.Endpoint, plan))
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating endpoint",
+			"Could not create endpoint, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan = newNeonEndpointResourceModel(result.Endpoint, plan)
+
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *NeonEndpointResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state neonEndpointResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	endpoint, err := r.client.EndpointRead(ctx, state.ProjectID.Value, state.ID.Value, neonApi.NeonApiClientOptions{})
+
+	if neonApi.IsNotFound(err) {
+		resp.Diagnostics.AddWarning(
+			"Endpoint not found",
+			fmt.Sprintf("Endpoint %s no longer exists in Neon and has been removed from the Terraform state. It will be created again on the next apply.", state.ID.Value),
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading endpoint",
+			"Could not read endpoint, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	state = newNeonEndpointResourceModel(endpoint, state)
+
+	// Save updated state into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *NeonEndpointResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan neonEndpointResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateData := neonApi.NeonEndpointUpdateData{
+		Endpoint: neonApi.NeonEndpointUpdateEndpointAttributes{
+			BranchID:   plan.BranchID.Value,
+			PoolerMode: plan.PoolerMode.Value,
+		},
+	}
+	if !plan.AutoscalingLimitMinCu.Null && !plan.AutoscalingLimitMinCu.Unknown {
+		updateData.Endpoint.AutoscalingLimitMinCu = &plan.AutoscalingLimitMinCu.Value
+	}
+	if !plan.AutoscalingLimitMaxCu.Null && !plan.AutoscalingLimitMaxCu.Unknown {
+		updateData.Endpoint.AutoscalingLimitMaxCu = &plan.AutoscalingLimitMaxCu.Value
+	}
+	if !plan.SuspendTimeoutSeconds.Null && !plan.SuspendTimeoutSeconds.Unknown {
+		updateData.Endpoint.SuspendTimeoutSeconds = &plan.SuspendTimeoutSeconds.Value
+	}
+	if !plan.PoolerEnabled.Null && !plan.PoolerEnabled.Unknown {
+		updateData.Endpoint.PoolerEnabled = &plan.PoolerEnabled.Value
+	}
+
+	result, err := r.client.EndpointUpdate(ctx, plan.ProjectID.Value, plan.ID.Value, updateData, neonApi.NeonApiClientOptions{})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update endpoint, got error: %s", err))
+		return
+	}
+
+	plan = newNeonEndpointResourceModel(result.Endpoint, plan)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *NeonEndpointResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state neonEndpointResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.EndpointDelete(ctx, state.ProjectID.Value, state.ID.Value, neonApi.NeonApiClientOptions{})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete endpoint, got error: %s", err))
+		return
+	}
+}
+
+// ImportState imports an endpoint by an ID of the form `project_id/endpoint_id`. Read fills in the other attributes.
+func (r *NeonEndpointResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, err := parseImportID(req.ID, "project_id", "endpoint_id")
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), parts[0])...)
+}
+
+// endpointRegionID returns the region to create an endpoint in. The endpoints API only takes platform-prefixed region IDs.
+func endpointRegionID(regionID types.String) string {
+	if regionID.Null || regionID.Unknown || regionID.Value == "" {
+		return ""
+	}
+	return neonApi.CanonicalRegionID(regionID.Value)
+}
+
+func newNeonEndpointResourceModel(endpoint neonApi.NeonEndpoint, prior neonEndpointResourceModel) neonEndpointResourceModel {
+	return neonEndpointResourceModel{
+		ID:                    types.String{Value: endpoint.ID},
+		ProjectID:             types.String{Value: endpoint.ProjectID},
+		BranchID:              types.String{Value: endpoint.BranchID},
+		Type:                  types.String{Value: endpoint.Type},
+		RegionID:              regionIDFromAPI(prior.RegionID, endpoint.RegionID),
+		AutoscalingLimitMinCu: types.Float64{Value: endpoint.AutoscalingLimitMinCu},
+		AutoscalingLimitMaxCu: types.Float64{Value: endpoint.AutoscalingLimitMaxCu},
+		SuspendTimeoutSeconds: types.Int64{Value: endpoint.SuspendTimeoutSeconds},
+		PoolerEnabled:         types.Bool{Value: endpoint.PoolerEnabled},
+		PoolerMode:            types.String{Value: endpoint.PoolerMode},
+		Host:                  types.String{Value: endpoint.Host},
+		CurrentState:          types.String{Value: endpoint.CurrentState},
+	}
+}
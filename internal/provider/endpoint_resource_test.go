@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNeonEndpointResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccNeonEndpointResourceConfig(1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("neon_endpoint.test", "id"),
+					resource.TestCheckResourceAttrSet("neon_endpoint.test", "host"),
+					resource.TestCheckResourceAttrPair("neon_endpoint.test", "branch_id", "neon_branch.test", "id"),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "autoscaling_limit_max_cu", "1"),
+				),
+			},
+
+			// Update and Read testing
+			{
+				Config: testAccNeonEndpointResourceConfig(2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_endpoint.test", "autoscaling_limit_max_cu", "2"),
+				),
+			},
+
+			// ImportState testing
+			{
+				ResourceName:      "neon_endpoint.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateID("neon_endpoint.test", "project_id", "id"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// TestNeonEndpointResourceImportState verifies endpoints are imported by a composite ID and fully read
+func TestNeonEndpointResourceImportState(t *testing.T) {
+	client, server := testNeonApiClient(t)
+	r := &NeonEndpointResource{client: client}
+
+	project := testCreateProject(t, client, "test-endpoints")
+	endpoint := server.Endpoints(project.Project.ID)[0]
+
+	resp, diags := testImportState(t, r, fmt.Sprintf("%s/%s", project.Project.ID, endpoint.ID))
+	testFailOnDiagnostics(t, diags)
+
+	var model neonEndpointResourceModel
+	testFailOnDiagnostics(t, resp.State.Get(context.Background(), &model))
+
+	if model.ID.Value != endpoint.ID || model.BranchID.Value != endpoint.BranchID || model.Type.Value != "read_write" || model.RegionID.Value != "aws-us-west-2" || model.Host.Value == "" {
+		t.Errorf("Expected imported endpoint to be fully read, got %+v", model)
+	}
+
+	if _, diags := testImportState(t, r, endpoint.ID); !diags.HasError() {
+		t.Errorf("Expected import ID without project to be rejected")
+	}
+}
+
+// TestNeonEndpointResourceValidateConfig verifies invalid endpoint types and autoscaling limits are rejected
+func TestNeonEndpointResourceValidateConfig(t *testing.T) {
+	r := &NeonEndpointResource{}
+
+	configs := map[string]neonEndpointResourceModel{
+		"invalid type": {
+			Type: types.String{Value: "read_mostly"},
+		},
+		"inverted autoscaling limits": {
+			Type:                  types.String{Value: "read_write"},
+			AutoscalingLimitMinCu: types.Float64{Value: 2},
+			AutoscalingLimitMaxCu: types.Float64{Value: 1},
+		},
+	}
+
+	for name, config := range configs {
+		state := testResourceState(t, r, &config)
+		resp := tfresource.ValidateConfigResponse{}
+
+		r.ValidateConfig(context.Background(), tfresource.ValidateConfigRequest{Config: tfsdk.Config{Schema: state.Schema, Raw: state.Raw}}, &resp)
+
+		if !resp.Diagnostics.HasError() {
+			t.Errorf("Expected %s to be rejected", name)
+		}
+	}
+}
+
+func testAccNeonEndpointResourceConfig(maxCu int) string {
+	return fmt.Sprintf(`
+	provider "neon" { }
+	resource "neon_project" "test" {
+		name = "test-endpoints"
+		instance_handle = "scalable"
+		platform_id = "aws"
+		region_id = "aws-us-west-2"
+	}
+
+	resource "neon_branch" "test" {
+		project_id = neon_project.test.id
+		name = "test-endpoints"
+	}
+
+	resource "neon_endpoint" "test" {
+		project_id = neon_project.test.id
+		branch_id = neon_branch.test.id
+		type = "read_write"
+		autoscaling_limit_min_cu = 0.25
+		autoscaling_limit_max_cu = %d
+	}
+`, maxCu)
+}
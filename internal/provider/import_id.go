@@ -0,0 +1,24 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseImportID splits a composite import ID such as `project_id/branch_id/name` into its parts.
+// The last part may contain slashes.
+func parseImportID(id string, parts ...string) ([]string, error) {
+	values := strings.SplitN(id, "/", len(parts))
+
+	if len(values) != len(parts) {
+		return nil, fmt.Errorf("Expected import ID of the form `%s`, got: %s", strings.Join(parts, "/"), id)
+	}
+
+	for i, value := range values {
+		if value == "" {
+			return nil, fmt.Errorf("Expected import ID of the form `%s`, got an empty %s: %s", strings.Join(parts, "/"), parts[i], id)
+		}
+	}
+
+	return values, nil
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestParseImportID(t *testing.T) {
+	parts, err := parseImportID("project-1/br-1/app/user", "project_id", "branch_id", "name")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Join(parts, ",") != "project-1,br-1,app/user" {
+		t.Errorf("Expected the last part to keep its slashes, got %v", parts)
+	}
+
+	invalid := map[string]string{
+		"br-1":        "project_id/branch_id",
+		"project-1/":  "empty branch_id",
+		"/br-1":       "empty project_id",
+		"project-1//": "empty branch_id",
+	}
+
+	for id, message := range invalid {
+		parts := []string{"project_id", "branch_id"}
+		if strings.Count(id, "/") == 2 {
+			parts = append(parts, "name")
+		}
+
+		_, err := parseImportID(id, parts...)
+
+		if err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("Expected import ID %q to be rejected with %q, got %v", id, message, err)
+		}
+	}
+}
+
+// testAccImportStateID returns the import ID of a resource, built from the given attributes joined by slashes.
+func testAccImportStateID(resourceName string, attributes ...string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("Resource not found. resource: %s", resourceName)
+		}
+
+		values := make([]string, 0, len(attributes))
+		for _, attribute := range attributes {
+			values = append(values, rs.Primary.Attributes[attribute])
+		}
+
+		return strings.Join(values, "/"), nil
+	}
+}
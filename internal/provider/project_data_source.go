@@ -0,0 +1,256 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"virtual-repetitions/terraform-provider-neon/src/neonApi"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &NeonProjectDataSource{}
+var _ datasource.DataSourceWithConfigure = &NeonProjectDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &NeonProjectDataSource{}
+
+func NewNeonProjectDataSource() datasource.DataSource {
+	return &NeonProjectDataSource{}
+}
+
+// NeonProjectDataSource defines the data source implementation.
+type NeonProjectDataSource struct {
+	client neonApi.NeonApiClient
+}
+
+// neonProjectDataSourceModel describes a project read by the neon_project and neon_projects data sources.
+type neonProjectDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	OrgID          types.String `tfsdk:"org_id"`
+	InstanceHandle types.String `tfsdk:"instance_handle"`
+	InstanceTypeID types.String `tfsdk:"instance_type_id"`
+	PlatformID     types.String `tfsdk:"platform_id"`
+	RegionID       types.String `tfsdk:"region_id"`
+	PoolerEnabled  types.Bool   `tfsdk:"pooler_enabled"`
+}
+
+func (d *NeonProjectDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project"
+}
+
+func (d *NeonProjectDataSource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	attributes := projectDataSourceAttributes()
+
+	attributes["id"] = tfsdk.Attribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: "ID of the project to look up. Exactly one of `id` and `name` must be set.",
+		Type:                types.StringType,
+	}
+	attributes["name"] = tfsdk.Attribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: "Exact name of the project to look up. The name must match a single project. Exactly one of `id` and `name` must be set.",
+		Type:                types.StringType,
+	}
+
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Looks up a Neon project by ID or name",
+
+		Attributes: attributes,
+	}, nil
+}
+
+func (d *NeonProjectDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config neonProjectDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Unknown values are only known at apply time, so they are assumed to be set.
+	hasID, hasName := !config.ID.Null, !config.Name.Null
+
+	if hasID == hasName {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"Invalid project lookup",
+			"Exactly one of `id` and `name` must be set.",
+		)
+	}
+}
+
+func (d *NeonProjectDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(neonApi.NeonApiClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected neonApi.NeonApiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *NeonProjectDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config neonProjectDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var project neonApi.NeonProject
+	var ok bool
+
+	if !config.ID.Null {
+		project, ok = d.readProjectByID(ctx, config.ID.Value, &resp.Diagnostics)
+	} else {
+		project, ok = d.readProjectByName(ctx, config.Name.Value, &resp.Diagnostics)
+	}
+
+	if !ok {
+		return
+	}
+
+	state := newNeonProjectDataSourceModel(project)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (d *NeonProjectDataSource) readProjectByID(ctx context.Context, projectID string, diags *diag.Diagnostics) (neonApi.NeonProject, bool) {
+	project, err := d.client.ProjectRead(ctx, projectID, neonApi.NeonApiClientOptions{})
+
+	if neonApi.IsNotFound(err) {
+		diags.AddError(
+			"Project not found",
+			fmt.Sprintf("Project %s does not exist in Neon or is not visible to the API key.", projectID),
+		)
+		return project, false
+	}
+
+	if err != nil {
+		diags.AddError(
+			"Error reading project",
+			"Could not read project, unexpected error: "+err.Error(),
+		)
+		return project, false
+	}
+
+	return project, true
+}
+
+func (d *NeonProjectDataSource) readProjectByName(ctx context.Context, name string, diags *diag.Diagnostics) (neonApi.NeonProject, bool) {
+	projects, err := d.client.ProjectList(ctx, neonApi.NeonProjectListParams{}, neonApi.NeonApiClientOptions{})
+
+	if err != nil {
+		diags.AddError(
+			"Error reading project",
+			"Could not list projects, unexpected error: "+err.Error(),
+		)
+		return neonApi.NeonProject{}, false
+	}
+
+	var matches []neonApi.NeonProject
+	var matchIDs []string
+	for _, project := range projects {
+		if project.Name == name {
+			matches = append(matches, project)
+			matchIDs = append(matchIDs, project.ID)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		diags.AddError(
+			"Project not found",
+			fmt.Sprintf("No project visible to the API key is named %s.", name),
+		)
+		return neonApi.NeonProject{}, false
+	case 1:
+		return matches[0], true
+	default:
+		diags.AddError(
+			"Multiple projects found",
+			fmt.Sprintf("%d projects are named %s. Look the project up by `id` instead, one of: %s", len(matches), name, strings.Join(matchIDs, ", ")),
+		)
+		return neonApi.NeonProject{}, false
+	}
+}
+
+// projectDataSourceAttributes returns the computed attributes describing a project.
+func projectDataSourceAttributes() map[string]tfsdk.Attribute {
+	return map[string]tfsdk.Attribute{
+		"id": {
+			Computed:            true,
+			MarkdownDescription: "Project ID",
+			Type:                types.StringType,
+		},
+		"name": {
+			Computed:            true,
+			MarkdownDescription: "Project name.",
+			Type:                types.StringType,
+		},
+		"org_id": {
+			Computed:            true,
+			MarkdownDescription: "ID of the organization owning the project. Empty for personal projects.",
+			Type:                types.StringType,
+		},
+		"instance_handle": {
+			Computed:            true,
+			MarkdownDescription: "Instance handle, for example `scalable`.",
+			Type:                types.StringType,
+		},
+		"instance_type_id": {
+			Computed:            true,
+			MarkdownDescription: "Instance type ID.",
+			Type:                types.StringType,
+		},
+		"platform_id": {
+			Computed:            true,
+			MarkdownDescription: "Cloud platform, for example `aws`.",
+			Type:                types.StringType,
+		},
+		"region_id": {
+			Computed:            true,
+			MarkdownDescription: "Region, for example `aws-us-west-2`.",
+			Type:                types.StringType,
+		},
+		"pooler_enabled": {
+			Computed:            true,
+			MarkdownDescription: "Whether connections go through the connection pooler.",
+			Type:                types.BoolType,
+		},
+	}
+}
+
+func newNeonProjectDataSourceModel(project neonApi.NeonProject) neonProjectDataSourceModel {
+	return neonProjectDataSourceModel{
+		ID:             types.String{Value: project.ID},
+		Name:           types.String{Value: project.Name},
+		OrgID:          types.String{Value: project.OrgID},
+		InstanceHandle: types.String{Value: project.InstanceHandle},
+		InstanceTypeID: types.String{Value: project.InstanceTypeID},
+		PlatformID:     types.String{Value: project.PlatformID},
+		RegionID:       types.String{Value: project.RegionID},
+		PoolerEnabled:  types.Bool{Value: project.PoolerEnabled},
+	}
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"virtual-repetitions/terraform-provider-neon/src/neonApi"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNeonProjectDataSource(t *testing.T) {
+	projectName := randomProjectName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNeonProjectDataSourceConfig(projectName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.neon_project.by_id", "name", "neon_project.test", "name"),
+					resource.TestCheckResourceAttrPair("data.neon_project.by_id", "region_id", "neon_project.test", "region_id"),
+					resource.TestCheckResourceAttrPair("data.neon_project.by_name", "id", "neon_project.test", "id"),
+					resource.TestCheckResourceAttr("data.neon_project.by_name", "platform_id", "aws"),
+				),
+			},
+		},
+	})
+}
+
+func TestNeonProjectDataSourceReadByName(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testNeonApiClient(t)
+	d := &NeonProjectDataSource{client: client}
+
+	projectIDs := map[string]string{}
+	for _, name := range []string{"alpha", "beta", "beta"} {
+		result, err := client.ProjectCreate(ctx, neonApi.NeonProjectCreateData{
+			Project: neonApi.NeonProjectCreateProjectAttributes{
+				InstanceHandle: "scalable",
+				Name:           name,
+				PlatformID:     "aws",
+				RegionID:       "aws-us-west-2",
+			},
+		}, neonApi.NeonApiClientOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		projectIDs[name] = result.Project.ID
+	}
+
+	resp := testDataSourceRead(t, d, testProjectDataSourceConfig(types.String{Null: true}, types.String{Value: "alpha"}))
+	testFailOnDiagnostics(t, resp.Diagnostics)
+
+	var state neonProjectDataSourceModel
+	testFailOnDiagnostics(t, resp.State.Get(ctx, &state))
+
+	if state.ID.Value != projectIDs["alpha"] || state.RegionID.Value != "aws-us-west-2" {
+		t.Errorf("Expected project alpha to be found, got %+v", state)
+	}
+
+	for name, summary := range map[string]string{"beta": "Multiple projects found", "gamma": "Project not found"} {
+		resp := testDataSourceRead(t, d, testProjectDataSourceConfig(types.String{Null: true}, types.String{Value: name}))
+
+		if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != summary {
+			t.Errorf("Expected looking up %s to fail with %s, got %v", name, summary, resp.Diagnostics)
+		}
+	}
+}
+
+func TestNeonProjectDataSourceValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	d := &NeonProjectDataSource{}
+
+	schema, diags := d.GetSchema(ctx)
+	testFailOnDiagnostics(t, diags)
+
+	configs := map[string]*neonProjectDataSourceModel{
+		"id and name":     testProjectDataSourceConfig(types.String{Value: "project"}, types.String{Value: "name"}),
+		"neither of them": testProjectDataSourceConfig(types.String{Null: true}, types.String{Null: true}),
+	}
+
+	for name, config := range configs {
+		state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+		testFailOnDiagnostics(t, state.Set(ctx, config))
+		resp := datasource.ValidateConfigResponse{}
+
+		d.ValidateConfig(ctx, datasource.ValidateConfigRequest{Config: tfsdk.Config{Schema: schema, Raw: state.Raw}}, &resp)
+
+		if !resp.Diagnostics.HasError() {
+			t.Errorf("Expected %s to be rejected", name)
+		}
+	}
+}
+
+// testProjectDataSourceConfig returns a neon_project data source config looking up the given ID or name.
+func testProjectDataSourceConfig(id types.String, name types.String) *neonProjectDataSourceModel {
+	return &neonProjectDataSourceModel{
+		ID:             id,
+		Name:           name,
+		OrgID:          types.String{Null: true},
+		InstanceHandle: types.String{Null: true},
+		InstanceTypeID: types.String{Null: true},
+		PlatformID:     types.String{Null: true},
+		RegionID:       types.String{Null: true},
+		PoolerEnabled:  types.Bool{Null: true},
+	}
+}
+
+func testAccNeonProjectDataSourceConfig(projectName string) string {
+	return fmt.Sprintf(`
+	provider "neon" { }
+	resource "neon_project" "test" {
+		name = "%s"
+		instance_handle = "scalable"
+		platform_id = "aws"
+		region_id = "aws-us-west-2"
+	}
+
+	data "neon_project" "by_id" {
+		id = neon_project.test.id
+	}
+
+	data "neon_project" "by_name" {
+		name = neon_project.test.name
+	}
+`, projectName)
+}
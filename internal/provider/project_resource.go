@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"virtual-repetitions/terraform-provider-neon/src/neonApi"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -16,6 +17,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &NeonProjectResource{}
 var _ resource.ResourceWithImportState = &NeonProjectResource{}
+var _ resource.ResourceWithModifyPlan = &NeonProjectResource{}
 
 func NewNeonProjectResource() resource.Resource {
 	return &NeonProjectResource{}
@@ -33,6 +35,16 @@ type neonProjectResourceModel struct {
 	Name           types.String `tfsdk:"name"`
 	PlatformID     types.String `tfsdk:"platform_id"`
 	RegionID       types.String `tfsdk:"region_id"`
+	InstanceTypeID types.String `tfsdk:"instance_type_id"`
+	PoolerEnabled  types.Bool   `tfsdk:"pooler_enabled"`
+	Settings       types.Map    `tfsdk:"settings"`
+
+	DefaultBranchID     types.String `tfsdk:"default_branch_id"`
+	DefaultEndpointHost types.String `tfsdk:"default_endpoint_host"`
+	DefaultRoleName     types.String `tfsdk:"default_role_name"`
+	DefaultRolePassword types.String `tfsdk:"default_role_password"`
+	DefaultDatabaseName types.String `tfsdk:"default_database_name"`
+	ConnectionURI       types.String `tfsdk:"connection_uri"`
 }
 
 func (r *NeonProjectResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -54,25 +66,138 @@ func (r *NeonProjectResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag
 				},
 			},
 			"instance_handle": {
-				Required: true,
-				Type:     types.StringType,
+				Required:            true,
+				MarkdownDescription: "Instance handle, for example `scalable`. Changing it creates a new project.",
+				Type:                types.StringType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.RequiresReplace(),
+				},
 			},
 			"name": {
-				Required: true,
-				Type:     types.StringType,
+				Required:            true,
+				MarkdownDescription: "Project name. Can be changed in place.",
+				Type:                types.StringType,
 			},
 			"platform_id": {
-				Required: true,
-				Type:     types.StringType,
+				Required:            true,
+				MarkdownDescription: "Cloud platform, for example `aws`. Changing it creates a new project.",
+				Type:                types.StringType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.RequiresReplace(),
+				},
 			},
 			"region_id": {
-				Required: true,
-				Type:     types.StringType,
+				// Terraform requires the planned value of a required attribute to match the configuration, so
+				// the prior form of an equivalent region cannot be planned here. Instead, the configured form
+				// is kept in state by Create, Update and Read, and switching between forms is an update that
+				// changes nothing in Neon.
+				Required:            true,
+				MarkdownDescription: "Region, for example `aws-us-west-2`. The platform prefix may be left out, as in `us-west-2`. Changing it to another region creates a new project.",
+				Type:                types.StringType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					regionIDRequiresReplace(),
+				},
+			},
+			"instance_type_id": {
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Instance type ID. Defaults to the instance type chosen by Neon. Can be changed in place.",
+				Type:                types.StringType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"pooler_enabled": {
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Whether connections go through the connection pooler. Can be changed in place.",
+				Type:                types.BoolType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"settings": {
+				Optional:            true,
+				MarkdownDescription: "Project settings. Can be changed in place. Only the configured settings are managed: settings removed from the configuration are cleared, and the other settings of the project are left alone.",
+				Type:                types.MapType{ElemType: types.StringType},
+			},
+			"default_branch_id": {
+				Computed:            true,
+				MarkdownDescription: "ID of the branch Neon created along with the project.",
+				Type:                types.StringType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"default_endpoint_host": {
+				Computed:            true,
+				MarkdownDescription: "Host of the read-write endpoint of the default branch.",
+				Type:                types.StringType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"default_role_name": {
+				Computed:            true,
+				MarkdownDescription: "Name of the role Neon created along with the project.",
+				Type:                types.StringType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"default_role_password": {
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Password of the default role.",
+				Type:                types.StringType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"default_database_name": {
+				Computed:            true,
+				MarkdownDescription: "Name of the database Neon created along with the project.",
+				Type:                types.StringType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"connection_uri": {
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Postgres connection URI of the default database, using the default role and endpoint.",
+				Type:                types.StringType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
 			},
 		},
 	}, nil
 }
 
+// ModifyPlan rejects regions missing from the region catalog when a project is created or moved to another region.
+func (r *NeonProjectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state neonProjectResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.RegionID.Null || !neonApi.EquivalentRegionIDs(state.RegionID.Value, plan.RegionID.Value) {
+		validateRegionID(ctx, r.client, path.Root("region_id"), plan.RegionID, &resp.Diagnostics)
+	}
+}
+
 func (r *NeonProjectResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -105,17 +230,22 @@ func (r *NeonProjectResource) Create(ctx context.Context, req resource.CreateReq
 
 	tflog.Debug(ctx, "Creating Neon project resource.")
 
-	result, err := r.client.ProjectCreate(neonApi.NeonProjectCreateData{
+	result, err := r.client.ProjectCreate(ctx, neonApi.NeonProjectCreateData{
 		Project: neonApi.NeonProjectCreateProjectAttributes{
 			InstanceHandle: plan.InstanceHandle.Value,
 			Name:           plan.Name.Value,
 			PlatformID:     plan.PlatformID.Value,
 			RegionID:       plan.RegionID.Value,
-			Settings:       map[string]string{},
+			Settings:       projectSettings(ctx, plan.Settings, &resp.Diagnostics),
 		},
-	}, neonApi.NeonApiClientOptions{
-		NumRetries: 0,
-	})
+	}, neonApi.NeonApiClientOptions{})
+
+	if err != nil && result.Project.ID != "" {
+		plan.ID = types.String{Value: result.Project.ID}
+		setProjectCreateDefaults(&plan, result.Response)
+		setProjectMutableAttributes(&plan, result.Project)
+		savePartiallyCreated(ctx, resp, plan)
+	}
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -127,6 +257,36 @@ func (r *NeonProjectResource) Create(ctx context.Context, req resource.CreateReq
 
 	plan.ID = types.String{Value: result.Project.ID}
 
+	setProjectCreateDefaults(&plan, result.Response)
+
+	// The instance type and pooler cannot be chosen on creation, so configured values are applied with an update.
+	if (!plan.InstanceTypeID.Unknown && plan.InstanceTypeID.Value != result.Project.InstanceTypeID) ||
+		(!plan.PoolerEnabled.Unknown && plan.PoolerEnabled.Value != result.Project.PoolerEnabled) {
+		created := result.Project
+		result, err = r.client.ProjectUpdate(ctx, created.ID, newProjectUpdateData(ctx, plan, plan.Settings, created, &resp.Diagnostics), neonApi.NeonApiClientOptions{})
+
+		if err != nil {
+			setProjectMutableAttributes(&plan, created)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+			resp.Diagnostics.AddError(
+				"Error creating project",
+				"Could not update the instance type or pooler of the new project, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	setProjectMutableAttributes(&plan, result.Project)
+
+	err = r.readProjectDefaults(ctx, &plan)
+
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Could not read project defaults",
+			"The project was created, but its default branch, endpoint, role or database could not be read: "+err.Error(),
+		)
+	}
+
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
 	tflog.Trace(ctx, "created a resource")
@@ -145,9 +305,16 @@ func (r *NeonProjectResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	project, err := r.client.ProjectRead(state.ID.Value, neonApi.NeonApiClientOptions{
-		NumRetries: 0,
-	})
+	project, err := r.client.ProjectRead(ctx, state.ID.Value, neonApi.NeonApiClientOptions{})
+
+	if neonApi.IsNotFound(err) {
+		resp.Diagnostics.AddWarning(
+			"Project not found",
+			fmt.Sprintf("Project %s no longer exists in Neon and has been removed from the Terraform state. It will be created again on the next apply.", state.ID.Value),
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -157,12 +324,24 @@ func (r *NeonProjectResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	state = neonProjectResourceModel{
-		ID:             state.ID,
-		InstanceHandle: types.String{Value: project.InstanceHandle},
-		Name:           types.String{Value: project.Name},
-		PlatformID:     types.String{Value: project.PlatformID},
-		RegionID:       types.String{Value: project.RegionID},
+	// The defaults are only known on creation, so they are kept from the prior state.
+	state.InstanceHandle = types.String{Value: project.InstanceHandle}
+	state.Name = types.String{Value: project.Name}
+	state.PlatformID = types.String{Value: project.PlatformID}
+	state.RegionID = regionIDFromAPI(state.RegionID, project.RegionID)
+	setProjectMutableAttributes(&state, project)
+
+	if state.DefaultBranchID.Null || state.DefaultBranchID.Value == "" {
+		// The project was imported. Look up the defaults once, they are kept from then on.
+		err = r.readProjectDefaults(ctx, &state)
+
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading project",
+				"Could not read project defaults, unexpected error: "+err.Error(),
+			)
+			return
+		}
 	}
 
 	// Save updated state into Terraform state
@@ -170,22 +349,34 @@ func (r *NeonProjectResource) Read(ctx context.Context, req resource.ReadRequest
 }
 
 func (r *NeonProjectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data neonProjectResourceModel
+	var plan, state neonProjectResourceModel
 
 	// Read Terraform plan data into the model
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	_, err := r.client.ProjectUpdate(data.ID.Value, neonApi.NeonProjectUpdateData{
-		Project: neonApi.NeonProjectUpdateProjectAttributes{
-			Name: data.Name.Value,
-		},
-	}, neonApi.NeonApiClientOptions{
-		NumRetries: 0,
-	})
+	// The settings that are not managed by Terraform are only known to the API.
+	current, err := r.client.ProjectRead(ctx, plan.ID.Value, neonApi.NeonApiClientOptions{})
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating project",
+			"Could not read project, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	updateData := newProjectUpdateData(ctx, plan, state.Settings, current, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.ProjectUpdate(ctx, plan.ID.Value, updateData, neonApi.NeonApiClientOptions{})
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -195,8 +386,11 @@ func (r *NeonProjectResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	plan.Name = types.String{Value: result.Project.Name}
+	setProjectMutableAttributes(&plan, result.Project)
+
 	// Save updated data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
 func (r *NeonProjectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -208,9 +402,7 @@ func (r *NeonProjectResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
-	err := r.client.ProjectDelete(state.ID.Value, neonApi.NeonApiClientOptions{
-		NumRetries: 0,
-	})
+	err := r.client.ProjectDelete(ctx, state.ID.Value, neonApi.NeonApiClientOptions{})
 
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete project, got error: %s", err))
@@ -221,3 +413,195 @@ func (r *NeonProjectResource) Delete(ctx context.Context, req resource.DeleteReq
 func (r *NeonProjectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// newProjectUpdateData sends every updatable attribute. Attributes that are not known yet keep their current value.
+// The settings in priorSettings that are no longer planned are cleared, the other current settings are kept.
+func newProjectUpdateData(ctx context.Context, plan neonProjectResourceModel, priorSettings types.Map, current neonApi.NeonProject, diags *diag.Diagnostics) neonApi.NeonProjectUpdateData {
+	settings := map[string]string{}
+	for key, value := range current.Settings {
+		settings[key] = value
+	}
+	if !plan.Settings.Unknown {
+		for key := range projectSettings(ctx, priorSettings, diags) {
+			delete(settings, key)
+		}
+		for key, value := range projectSettings(ctx, plan.Settings, diags) {
+			settings[key] = value
+		}
+	}
+
+	data := neonApi.NeonProjectUpdateData{
+		Project: neonApi.NeonProjectUpdateProjectAttributes{
+			InstanceTypeID: current.InstanceTypeID,
+			Name:           plan.Name.Value,
+			PoolerEnabled:  current.PoolerEnabled,
+			Settings:       settings,
+		},
+	}
+
+	if !plan.InstanceTypeID.Unknown && !plan.InstanceTypeID.Null {
+		data.Project.InstanceTypeID = plan.InstanceTypeID.Value
+	}
+	if !plan.PoolerEnabled.Unknown && !plan.PoolerEnabled.Null {
+		data.Project.PoolerEnabled = plan.PoolerEnabled.Value
+	}
+
+	return data
+}
+
+// projectSettings converts the settings attribute for the API. Settings that are not known yet are sent empty.
+func projectSettings(ctx context.Context, value types.Map, diags *diag.Diagnostics) map[string]string {
+	settings := map[string]string{}
+
+	if value.Unknown || value.Null {
+		return settings
+	}
+
+	diags.Append(value.ElementsAs(ctx, &settings, false)...)
+
+	return settings
+}
+
+// setProjectMutableAttributes sets the updatable attributes other than the name from the API. Only the settings
+// already in the model are managed by Terraform, so the others are left out, unless the model settings are not
+// known yet.
+func setProjectMutableAttributes(model *neonProjectResourceModel, project neonApi.NeonProject) {
+	model.InstanceTypeID = types.String{Value: project.InstanceTypeID}
+	model.PoolerEnabled = types.Bool{Value: project.PoolerEnabled}
+
+	if model.Settings.Null {
+		return
+	}
+
+	settings := map[string]attr.Value{}
+	for key, value := range project.Settings {
+		if _, ok := model.Settings.Elems[key]; ok || model.Settings.Unknown {
+			settings[key] = types.String{Value: value}
+		}
+	}
+
+	model.Settings = types.Map{ElemType: types.StringType, Elems: settings}
+}
+
+// setProjectCreateDefaults sets the default role and database from the create response, which is the only
+// response including them and the only one revealing the role password. The other defaults are set to null
+// until readProjectDefaults looks them up.
+func setProjectCreateDefaults(model *neonProjectResourceModel, response neonApi.NeonProjectMutationSuccessResponse) {
+	model.DefaultBranchID = types.String{Null: true}
+	model.DefaultEndpointHost = types.String{Null: true}
+	model.DefaultRoleName = types.String{Null: true}
+	model.DefaultRolePassword = types.String{Null: true}
+	model.DefaultDatabaseName = types.String{Null: true}
+	model.ConnectionURI = types.String{Null: true}
+
+	if len(response.Roles) > 0 {
+		model.DefaultRoleName = types.String{Value: response.Roles[0].Name}
+		model.DefaultRolePassword = types.String{Value: response.Roles[0].Password}
+	}
+	if len(response.Databases) > 0 {
+		model.DefaultDatabaseName = types.String{Value: response.Databases[0].Name}
+	}
+}
+
+// readProjectDefaults fills in the default branch, endpoint, role and database of the project that are not set
+// yet, and derives the connection URI from them. Attributes without a matching object are set to null.
+func (r *NeonProjectResource) readProjectDefaults(ctx context.Context, model *neonProjectResourceModel) error {
+	projectID := model.ID.Value
+
+	if model.DefaultBranchID.Null || model.DefaultBranchID.Unknown || model.DefaultBranchID.Value == "" {
+		model.DefaultBranchID = types.String{Null: true}
+
+		branches, err := r.client.BranchList(ctx, projectID, neonApi.NeonApiClientOptions{})
+		if err != nil {
+			return err
+		}
+
+		for _, branch := range branches {
+			if branch.Default {
+				model.DefaultBranchID = types.String{Value: branch.ID}
+			}
+		}
+	}
+
+	if model.DefaultBranchID.Null {
+		model.DefaultEndpointHost = types.String{Null: true}
+		model.DefaultRoleName = optionalKnownString(model.DefaultRoleName)
+		model.DefaultRolePassword = optionalKnownString(model.DefaultRolePassword)
+		model.DefaultDatabaseName = optionalKnownString(model.DefaultDatabaseName)
+		model.ConnectionURI = types.String{Null: true}
+		return nil
+	}
+
+	branchID := model.DefaultBranchID.Value
+
+	if model.DefaultEndpointHost.Null || model.DefaultEndpointHost.Unknown || model.DefaultEndpointHost.Value == "" {
+		model.DefaultEndpointHost = types.String{Null: true}
+
+		endpoints, err := r.client.EndpointList(ctx, projectID, neonApi.NeonApiClientOptions{})
+		if err != nil {
+			return err
+		}
+
+		for _, endpoint := range endpoints {
+			if endpoint.BranchID == branchID && endpoint.Type == neonApi.NeonEndpointTypeReadWrite {
+				model.DefaultEndpointHost = types.String{Value: endpoint.Host}
+			}
+		}
+	}
+
+	if model.DefaultRoleName.Null || model.DefaultRoleName.Unknown || model.DefaultRoleName.Value == "" {
+		model.DefaultRoleName = types.String{Null: true}
+		model.DefaultRolePassword = types.String{Null: true}
+
+		roles, err := r.client.RoleList(ctx, projectID, branchID, neonApi.NeonApiClientOptions{})
+		if err != nil {
+			return err
+		}
+
+		if len(roles) > 0 {
+			password, err := r.client.RoleRevealPassword(ctx, projectID, branchID, roles[0].Name, neonApi.NeonApiClientOptions{})
+			if err != nil {
+				return err
+			}
+
+			model.DefaultRoleName = types.String{Value: roles[0].Name}
+			model.DefaultRolePassword = types.String{Value: password}
+		}
+	}
+
+	if model.DefaultDatabaseName.Null || model.DefaultDatabaseName.Unknown || model.DefaultDatabaseName.Value == "" {
+		model.DefaultDatabaseName = types.String{Null: true}
+
+		databases, err := r.client.DatabaseList(ctx, projectID, branchID, neonApi.NeonApiClientOptions{})
+		if err != nil {
+			return err
+		}
+
+		if len(databases) > 0 {
+			model.DefaultDatabaseName = types.String{Value: databases[0].Name}
+		}
+	}
+
+	model.ConnectionURI = types.String{Null: true}
+	if !model.DefaultEndpointHost.Null && !model.DefaultRoleName.Null && !model.DefaultDatabaseName.Null {
+		params := connectionParams{
+			Host:         model.DefaultEndpointHost.Value,
+			RoleName:     model.DefaultRoleName.Value,
+			Password:     model.DefaultRolePassword.Value,
+			DatabaseName: model.DefaultDatabaseName.Value,
+			// Neon only accepts TLS connections.
+			SslMode: "require",
+		}
+		model.ConnectionURI = types.String{Value: params.postgresURI("postgresql", false)}
+	}
+
+	return nil
+}
+
+// optionalKnownString replaces an unknown or empty value with null.
+func optionalKnownString(value types.String) types.String {
+	if value.Unknown || value.Value == "" {
+		return types.String{Null: true}
+	}
+	return value
+}
@@ -1,10 +1,18 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
+	"reflect"
 	"testing"
+	"virtual-repetitions/terraform-provider-neon/src/neonApi"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -18,6 +26,10 @@ func TestAccNeonProjectResource(t *testing.T) {
 				Config: testAccNeonProjectResourceConfig(randomProjectName()),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttrSet("neon_project.test", "id"),
+					resource.TestCheckResourceAttrSet("neon_project.test", "default_branch_id"),
+					resource.TestCheckResourceAttrSet("neon_project.test", "default_endpoint_host"),
+					resource.TestCheckResourceAttrSet("neon_project.test", "default_role_password"),
+					resource.TestCheckResourceAttrSet("neon_project.test", "connection_uri"),
 				),
 			},
 
@@ -28,8 +40,146 @@ func TestAccNeonProjectResource(t *testing.T) {
 					resource.TestCheckResourceAttr("neon_project.test", "name", "updated-project-name"),
 				),
 			},
+
+			// Update of the pooler and settings in place
+			{
+				Config: testAccNeonProjectResourcePoolerConfig("updated-project-name"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_project.test", "pooler_enabled", "true"),
+					resource.TestCheckResourceAttr("neon_project.test", "settings.quota", "1"),
+				),
+			},
+		},
+	})
+}
+
+// TestNeonProjectResourceUpdate verifies every updatable attribute is sent on update
+func TestNeonProjectResourceUpdate(t *testing.T) {
+	ctx := context.Background()
+	client, server := testNeonApiClient(t)
+	r := &NeonProjectResource{client: client}
+
+	result, err := client.ProjectCreate(ctx, neonApi.NeonProjectCreateData{
+		Project: neonApi.NeonProjectCreateProjectAttributes{
+			InstanceHandle: "scalable",
+			Name:           "update",
+			PlatformID:     "aws",
+			RegionID:       "aws-us-west-2",
 		},
+	}, neonApi.NeonApiClientOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	model := neonProjectResourceModel{
+		ID:             types.String{Value: result.Project.ID},
+		InstanceHandle: types.String{Value: "scalable"},
+		Name:           types.String{Value: "update"},
+		PlatformID:     types.String{Value: "aws"},
+		RegionID:       types.String{Value: "aws-us-west-2"},
+		InstanceTypeID: types.String{Value: result.Project.InstanceTypeID},
+		PoolerEnabled:  types.Bool{Value: false},
+		Settings:       types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{}},
+	}
+	state := testResourceState(t, r, &model)
+
+	model.Name = types.String{Value: "updated"}
+	model.InstanceTypeID = types.String{Value: "2"}
+	model.PoolerEnabled = types.Bool{Value: true}
+	model.Settings = types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{"quota": types.String{Value: "1"}}}
+	planned := testResourceState(t, r, &model)
+
+	resp := tfresource.UpdateResponse{State: state}
+	r.Update(ctx, tfresource.UpdateRequest{State: state, Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, &resp)
+	testFailOnDiagnostics(t, resp.Diagnostics)
+
+	project, _ := server.Project(result.Project.ID)
+	if project.Name != "updated" || project.InstanceTypeID != "2" || !project.PoolerEnabled || project.Settings["quota"] != "1" {
+		t.Errorf("Expected every updatable attribute to be sent, got %+v", project)
+	}
+}
+
+// TestNeonProjectResourceReadRemovesMissingProject verifies a project deleted outside of Terraform is removed from state
+func TestNeonProjectResourceReadRemovesMissingProject(t *testing.T) {
+	client, _ := testNeonApiClient(t)
+	r := &NeonProjectResource{client: client}
+
+	state := testResourceState(t, r, &neonProjectResourceModel{
+		ID:             types.String{Value: "deleted-project"},
+		InstanceHandle: types.String{Value: "scalable"},
+		Name:           types.String{Value: "deleted"},
+		PlatformID:     types.String{Value: "aws"},
+		RegionID:       types.String{Value: "aws-us-west-2"},
+		Settings:       types.Map{ElemType: types.StringType, Null: true},
+	})
+	resp := tfresource.ReadResponse{State: state}
+
+	r.Read(context.Background(), tfresource.ReadRequest{State: state}, &resp)
+
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("Expected a single warning, got %v", resp.Diagnostics)
+	}
+
+	if !resp.State.Raw.IsNull() {
+		t.Errorf("Expected project to be removed from state")
+	}
+}
+
+// TestNeonProjectResourceDefaults verifies the default connection details are set on creation and kept on Read
+func TestNeonProjectResourceDefaults(t *testing.T) {
+	ctx := context.Background()
+	client, server := testNeonApiClient(t)
+	r := &NeonProjectResource{client: client}
+
+	unknown := types.String{Unknown: true}
+	planned := testResourceState(t, r, &neonProjectResourceModel{
+		ID:                  unknown,
+		InstanceHandle:      types.String{Value: "scalable"},
+		Name:                types.String{Value: "defaults"},
+		PlatformID:          types.String{Value: "aws"},
+		RegionID:            types.String{Value: "aws-us-west-2"},
+		InstanceTypeID:      unknown,
+		PoolerEnabled:       types.Bool{Unknown: true},
+		Settings:            types.Map{ElemType: types.StringType, Unknown: true},
+		DefaultBranchID:     unknown,
+		DefaultEndpointHost: unknown,
+		DefaultRoleName:     unknown,
+		DefaultRolePassword: unknown,
+		DefaultDatabaseName: unknown,
+		ConnectionURI:       unknown,
 	})
+	createResp := tfresource.CreateResponse{State: planned}
+	createResp.State.Raw = tftypes.NewValue(planned.Schema.Type().TerraformType(ctx), nil)
+
+	r.Create(ctx, tfresource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, &createResp)
+
+	var created neonProjectResourceModel
+	testFailOnDiagnostics(t, createResp.Diagnostics)
+	testFailOnDiagnostics(t, createResp.State.Get(ctx, &created))
+
+	branch := server.Branches(created.ID.Value)[0]
+	endpoint := server.Endpoints(created.ID.Value)[0]
+	role := server.Roles(branch.ID)[0]
+
+	if created.DefaultBranchID.Value != branch.ID || created.DefaultEndpointHost.Value != endpoint.Host {
+		t.Errorf("Expected default branch %s and host %s, got %+v", branch.ID, endpoint.Host, created)
+	}
+
+	expectedURI := fmt.Sprintf("postgresql://%s:%s@%s/neondb?sslmode=require", role.Name, role.Password, endpoint.Host)
+	if created.ConnectionURI.Value != expectedURI {
+		t.Errorf("Expected connection URI %s, got %s", expectedURI, created.ConnectionURI.Value)
+	}
+
+	readResp := tfresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, tfresource.ReadRequest{State: createResp.State}, &readResp)
+
+	var read neonProjectResourceModel
+	testFailOnDiagnostics(t, readResp.Diagnostics)
+	testFailOnDiagnostics(t, readResp.State.Get(ctx, &read))
+
+	if !reflect.DeepEqual(read, created) {
+		t.Errorf("Expected defaults to be kept on Read. expected %+v, got %+v", created, read)
+	}
 }
 
 func testAccNeonProjectResourceConfig(projectName string) string {
@@ -46,6 +196,185 @@ func testAccNeonProjectResourceConfig(projectName string) string {
 	return config
 }
 
+func testAccNeonProjectResourcePoolerConfig(projectName string) string {
+
+	config := fmt.Sprintf(`
+	provider "neon" { }
+	resource "neon_project" "test" {
+		name = "%s"
+		instance_handle = "scalable"
+		platform_id = "aws"
+		region_id = "aws-us-west-2"
+		pooler_enabled = true
+		settings = {
+			quota = "1"
+		}
+	}
+`, projectName)
+	return config
+}
+
 func randomProjectName() string {
 	return fmt.Sprintf("Test Project %d", rand.Intn(10000))
 }
+
+func TestNeonProjectResourceModifyPlanValidatesRegion(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testNeonApiClient(t)
+	r := &NeonProjectResource{client: client}
+
+	schema, diags := r.GetSchema(ctx)
+	testFailOnDiagnostics(t, diags)
+	state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+
+	for regionID, valid := range map[string]bool{"aws-us-west-2": true, "us-west-2": true, "us-west-1": false, "mars-north-1": false} {
+		planned := testResourceState(t, r, &neonProjectResourceModel{
+			RegionID: types.String{Value: regionID},
+			Settings: types.Map{ElemType: types.StringType, Null: true},
+		})
+		plan := tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}
+		resp := tfresource.ModifyPlanResponse{Plan: plan}
+
+		r.ModifyPlan(ctx, tfresource.ModifyPlanRequest{Config: tfsdk.Config{Schema: planned.Schema, Raw: planned.Raw}, State: state, Plan: plan}, &resp)
+
+		if resp.Diagnostics.HasError() == valid {
+			t.Errorf("Expected region %s to be valid: %t, got %v", regionID, valid, resp.Diagnostics)
+		}
+	}
+}
+
+// TestNeonProjectResourceIgnoresUnmanagedSettings verifies settings Neon reports without them being configured give an empty plan
+func TestNeonProjectResourceIgnoresUnmanagedSettings(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testNeonApiClient(t)
+	r := &NeonProjectResource{client: client}
+
+	result, err := client.ProjectCreate(ctx, neonApi.NeonProjectCreateData{
+		Project: neonApi.NeonProjectCreateProjectAttributes{
+			InstanceHandle: "scalable",
+			Name:           "settings",
+			PlatformID:     "aws",
+			RegionID:       "aws-us-west-2",
+			Settings:       map[string]string{"quota": "1"},
+		},
+	}, neonApi.NeonApiClientOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := testResourceState(t, r, &neonProjectResourceModel{
+		ID:              types.String{Value: result.Project.ID},
+		InstanceHandle:  types.String{Value: "scalable"},
+		Name:            types.String{Value: "settings"},
+		PlatformID:      types.String{Value: "aws"},
+		RegionID:        types.String{Value: "aws-us-west-2"},
+		Settings:        types.Map{ElemType: types.StringType, Null: true},
+		DefaultBranchID: types.String{Value: "main"},
+	})
+	readResp := tfresource.ReadResponse{State: state}
+	r.Read(ctx, tfresource.ReadRequest{State: state}, &readResp)
+	testFailOnDiagnostics(t, readResp.Diagnostics)
+
+	var read neonProjectResourceModel
+	testFailOnDiagnostics(t, readResp.State.Get(ctx, &read))
+	if !read.Settings.Null {
+		t.Fatalf("Expected the unmanaged settings to be left out of the state, got %v", read.Settings)
+	}
+
+	// The configuration matches the refreshed state, which is also the plan of the non-computed attributes.
+	config := testResourceState(t, r, &read)
+	plan := tfsdk.Plan{Schema: config.Schema, Raw: config.Raw}
+	resp := tfresource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, tfresource.ModifyPlanRequest{Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw}, State: readResp.State, Plan: plan}, &resp)
+	testFailOnDiagnostics(t, resp.Diagnostics)
+
+	if !resp.Plan.Raw.Equal(readResp.State.Raw) {
+		t.Errorf("Expected an empty plan, got %v for state %v", resp.Plan.Raw, readResp.State.Raw)
+	}
+}
+
+// TestNeonProjectResourceUpdateClearsRemovedSettings verifies settings removed from the configuration are cleared and the others kept
+func TestNeonProjectResourceUpdateClearsRemovedSettings(t *testing.T) {
+	ctx := context.Background()
+	client, server := testNeonApiClient(t)
+	r := &NeonProjectResource{client: client}
+
+	result, err := client.ProjectCreate(ctx, neonApi.NeonProjectCreateData{
+		Project: neonApi.NeonProjectCreateProjectAttributes{
+			InstanceHandle: "scalable",
+			Name:           "settings",
+			PlatformID:     "aws",
+			RegionID:       "aws-us-west-2",
+			Settings:       map[string]string{"quota": "1", "unmanaged": "2"},
+		},
+	}, neonApi.NeonApiClientOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	model := neonProjectResourceModel{
+		ID:             types.String{Value: result.Project.ID},
+		InstanceHandle: types.String{Value: "scalable"},
+		Name:           types.String{Value: "settings"},
+		PlatformID:     types.String{Value: "aws"},
+		RegionID:       types.String{Value: "aws-us-west-2"},
+		InstanceTypeID: types.String{Value: result.Project.InstanceTypeID},
+		PoolerEnabled:  types.Bool{Value: result.Project.PoolerEnabled},
+		Settings:       types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{"quota": types.String{Value: "1"}}},
+	}
+	state := testResourceState(t, r, &model)
+
+	model.Settings = types.Map{ElemType: types.StringType, Null: true}
+	planned := testResourceState(t, r, &model)
+
+	resp := tfresource.UpdateResponse{State: state}
+	r.Update(ctx, tfresource.UpdateRequest{State: state, Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, &resp)
+	testFailOnDiagnostics(t, resp.Diagnostics)
+
+	project, _ := server.Project(result.Project.ID)
+	if _, ok := project.Settings["quota"]; ok || project.Settings["unmanaged"] != "2" {
+		t.Errorf("Expected only the removed setting to be cleared, got %v", project.Settings)
+	}
+
+	var updated neonProjectResourceModel
+	testFailOnDiagnostics(t, resp.State.Get(ctx, &updated))
+	if !updated.Settings.Null {
+		t.Errorf("Expected the settings to be left out of the state, got %v", updated.Settings)
+	}
+}
+
+func TestNeonProjectResourceReadKeepsRegionForm(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testNeonApiClient(t)
+	r := &NeonProjectResource{client: client}
+
+	result, err := client.ProjectCreate(ctx, neonApi.NeonProjectCreateData{
+		Project: neonApi.NeonProjectCreateProjectAttributes{
+			InstanceHandle: "scalable",
+			Name:           "region",
+			PlatformID:     "aws",
+			RegionID:       "us-west-2",
+		},
+	}, neonApi.NeonApiClientOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := testResourceState(t, r, &neonProjectResourceModel{
+		ID:              types.String{Value: result.Project.ID},
+		RegionID:        types.String{Value: "us-west-2"},
+		Settings:        types.Map{ElemType: types.StringType, Null: true},
+		DefaultBranchID: types.String{Value: "main"},
+	})
+	resp := tfresource.ReadResponse{State: state}
+
+	r.Read(ctx, tfresource.ReadRequest{State: state}, &resp)
+	testFailOnDiagnostics(t, resp.Diagnostics)
+
+	var model neonProjectResourceModel
+	testFailOnDiagnostics(t, resp.State.Get(ctx, &model))
+
+	if model.RegionID.Value != "us-west-2" {
+		t.Errorf("Expected the configured region form to be kept, got %s (API reports %s)", model.RegionID.Value, result.Project.RegionID)
+	}
+}
@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"virtual-repetitions/terraform-provider-neon/src/neonApi"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &NeonProjectsDataSource{}
+var _ datasource.DataSourceWithConfigure = &NeonProjectsDataSource{}
+
+func NewNeonProjectsDataSource() datasource.DataSource {
+	return &NeonProjectsDataSource{}
+}
+
+// NeonProjectsDataSource defines the data source implementation.
+type NeonProjectsDataSource struct {
+	client neonApi.NeonApiClient
+}
+
+// neonProjectsDataSourceModel describes the data source data model.
+type neonProjectsDataSourceModel struct {
+	ID         types.String                 `tfsdk:"id"`
+	NamePrefix types.String                 `tfsdk:"name_prefix"`
+	RegionID   types.String                 `tfsdk:"region_id"`
+	OrgID      types.String                 `tfsdk:"org_id"`
+	Projects   []neonProjectDataSourceModel `tfsdk:"projects"`
+}
+
+func (d *NeonProjectsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_projects"
+}
+
+func (d *NeonProjectsDataSource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists the Neon projects visible to the API key",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "Always `projects`.",
+				Type:                types.StringType,
+			},
+			"name_prefix": {
+				Optional:            true,
+				MarkdownDescription: "Only list projects whose name starts with this prefix.",
+				Type:                types.StringType,
+			},
+			"region_id": {
+				Optional:            true,
+				MarkdownDescription: "Only list projects in this region, for example `aws-us-west-2`. The platform prefix may be left out, as in `us-west-2`.",
+				Type:                types.StringType,
+			},
+			"org_id": {
+				Optional:            true,
+				MarkdownDescription: "Only list projects of this organization.",
+				Type:                types.StringType,
+			},
+			"projects": {
+				Computed:            true,
+				MarkdownDescription: "Matching projects, ordered as listed by Neon.",
+				Attributes:          tfsdk.ListNestedAttributes(projectDataSourceAttributes()),
+			},
+		},
+	}, nil
+}
+
+func (d *NeonProjectsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(neonApi.NeonApiClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected neonApi.NeonApiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *NeonProjectsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state neonProjectsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateRegionID(ctx, d.client, path.Root("region_id"), state.RegionID, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The API filters by organization, the other filters are applied to the listed projects.
+	projects, err := d.client.ProjectList(ctx, neonApi.NeonProjectListParams{OrgID: state.OrgID.Value}, neonApi.NeonApiClientOptions{})
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading projects",
+			"Could not list projects, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	state.ID = types.String{Value: "projects"}
+	state.Projects = []neonProjectDataSourceModel{}
+
+	for _, project := range projects {
+		if !strings.HasPrefix(project.Name, state.NamePrefix.Value) {
+			continue
+		}
+		if !state.RegionID.Null && !neonApi.EquivalentRegionIDs(project.RegionID, state.RegionID.Value) {
+			continue
+		}
+
+		state.Projects = append(state.Projects, newNeonProjectDataSourceModel(project))
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"virtual-repetitions/terraform-provider-neon/src/neonApi"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNeonProjectsDataSource(t *testing.T) {
+	projectName := randomProjectName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNeonProjectsDataSourceConfig(projectName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.neon_projects.test", "projects.#", "1"),
+					resource.TestCheckResourceAttrPair("data.neon_projects.test", "projects.0.id", "neon_project.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestNeonProjectsDataSourceFilters(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testNeonApiClient(t)
+	d := &NeonProjectsDataSource{client: client}
+
+	projects := []neonApi.NeonProjectCreateProjectAttributes{
+		{Name: "app-staging", RegionID: "us-west-2", OrgID: "org-app"},
+		{Name: "app-production", RegionID: "eu-central-1", OrgID: "org-app"},
+		{Name: "sandbox", RegionID: "us-west-2"},
+	}
+	for _, project := range projects {
+		project.InstanceHandle = "scalable"
+		project.PlatformID = "aws"
+
+		if _, err := client.ProjectCreate(ctx, neonApi.NeonProjectCreateData{Project: project}, neonApi.NeonApiClientOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	filters := map[string]struct {
+		config   neonProjectsDataSourceModel
+		expected []string
+	}{
+		"none": {
+			config:   neonProjectsDataSourceModel{NamePrefix: types.String{Null: true}, RegionID: types.String{Null: true}, OrgID: types.String{Null: true}},
+			expected: []string{"app-staging", "app-production", "sandbox"},
+		},
+		"name prefix": {
+			config:   neonProjectsDataSourceModel{NamePrefix: types.String{Value: "app-"}, RegionID: types.String{Null: true}, OrgID: types.String{Null: true}},
+			expected: []string{"app-staging", "app-production"},
+		},
+		"region": {
+			config:   neonProjectsDataSourceModel{NamePrefix: types.String{Null: true}, RegionID: types.String{Value: "us-west-2"}, OrgID: types.String{Null: true}},
+			expected: []string{"app-staging", "sandbox"},
+		},
+		"organization and region": {
+			config:   neonProjectsDataSourceModel{NamePrefix: types.String{Null: true}, RegionID: types.String{Value: "aws-eu-central-1"}, OrgID: types.String{Value: "org-app"}},
+			expected: []string{"app-production"},
+		},
+	}
+
+	for name, filter := range filters {
+		resp := testDataSourceRead(t, d, &filter.config)
+		testFailOnDiagnostics(t, resp.Diagnostics)
+
+		var state neonProjectsDataSourceModel
+		testFailOnDiagnostics(t, resp.State.Get(ctx, &state))
+
+		var names []string
+		for _, project := range state.Projects {
+			names = append(names, project.Name.Value)
+		}
+
+		if fmt.Sprint(names) != fmt.Sprint(filter.expected) {
+			t.Errorf("Expected filter %s to list %v, got %v", name, filter.expected, names)
+		}
+	}
+
+	resp := testDataSourceRead(t, d, &neonProjectsDataSourceModel{NamePrefix: types.String{Null: true}, RegionID: types.String{Value: "us-west-1"}, OrgID: types.String{Null: true}})
+
+	if !resp.Diagnostics.HasError() {
+		t.Errorf("Expected an unknown region to be rejected")
+	}
+}
+
+func testAccNeonProjectsDataSourceConfig(projectName string) string {
+	return fmt.Sprintf(`
+	provider "neon" { }
+	resource "neon_project" "test" {
+		name = "%s"
+		instance_handle = "scalable"
+		platform_id = "aws"
+		region_id = "aws-us-west-2"
+	}
+
+	data "neon_projects" "test" {
+		name_prefix = neon_project.test.name
+	}
+`, projectName)
+}
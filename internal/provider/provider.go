@@ -2,7 +2,9 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"time"
 	"virtual-repetitions/terraform-provider-neon/src/neonApi"
 
 	reqPkg "github.com/imroc/req/v3"
@@ -14,6 +16,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure NeonProvider satisfies various provider interfaces.
@@ -30,7 +33,12 @@ type NeonProvider struct {
 
 // NeonProviderModel describes the provider data model.
 type providerModel struct {
-	ApiKey types.String `tfsdk:"api_key"`
+	ApiKey                types.String  `tfsdk:"api_key"`
+	ApiURL                types.String  `tfsdk:"api_url"`
+	MaxRetries            types.Int64   `tfsdk:"max_retries"`
+	RetryMaxWait          types.Int64   `tfsdk:"retry_max_wait"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
 }
 
 func (p *NeonProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -46,6 +54,31 @@ func (p *NeonProvider) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagno
 				Optional:            true,
 				Type:                types.StringType,
 			},
+			"api_url": {
+				MarkdownDescription: "Base URL of the Neon API. Defaults to `" + neonApi.DefaultNeonApiURL + "`. Can also be set with the `NEON_API_HOST` environment variable.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"max_retries": {
+				MarkdownDescription: "Maximum number of times a Neon API request is retried when the API is rate limiting, the project is locked by running operations or the API is temporarily unavailable. Defaults to `5`.",
+				Optional:            true,
+				Type:                types.Int64Type,
+			},
+			"retry_max_wait": {
+				MarkdownDescription: "Maximum number of seconds to wait between retries of a Neon API request, including waits requested by the API through `Retry-After`. Defaults to `30`.",
+				Optional:            true,
+				Type:                types.Int64Type,
+			},
+			"max_concurrent_requests": {
+				MarkdownDescription: "Maximum number of Neon API requests in flight at once, across all resources and data sources. Further requests wait for a free slot. Requests waiting for the `requests_per_second` limit do not take a slot. Unlimited by default.",
+				Optional:            true,
+				Type:                types.Int64Type,
+			},
+			"requests_per_second": {
+				MarkdownDescription: "Maximum number of Neon API requests sent per second, including retries. Bursts of up to one second worth of requests are allowed. Unlimited by default.",
+				Optional:            true,
+				Type:                types.Float64Type,
+			},
 		},
 	}, nil
 }
@@ -71,6 +104,51 @@ func (p *NeonProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		)
 	}
 
+	if config.ApiURL.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_url"),
+			"Unknown Neon API URL",
+			"The provider cannot create the Neon API client as there is an unknown configuration value for the Neon API URL. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the NEON_API_HOST environment variable.",
+		)
+	}
+
+	if config.MaxRetries.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_retries"),
+			"Unknown Neon API max retries",
+			"The provider cannot create the Neon API client as there is an unknown configuration value for max_retries. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.RetryMaxWait.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_max_wait"),
+			"Unknown Neon API retry max wait",
+			"The provider cannot create the Neon API client as there is an unknown configuration value for retry_max_wait. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.MaxConcurrentRequests.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Unknown Neon API max concurrent requests",
+			"The provider cannot create the Neon API client as there is an unknown configuration value for max_concurrent_requests. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.RequestsPerSecond.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("requests_per_second"),
+			"Unknown Neon API requests per second",
+			"The provider cannot create the Neon API client as there is an unknown configuration value for requests_per_second. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -84,6 +162,16 @@ func (p *NeonProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		neonApiKey = config.ApiKey.Value
 	}
 
+	neonApiURL := os.Getenv("NEON_API_HOST")
+
+	if !config.ApiURL.IsNull() {
+		neonApiURL = config.ApiURL.Value
+	}
+
+	if neonApiURL == "" {
+		neonApiURL = neonApi.DefaultNeonApiURL
+	}
+
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
@@ -97,12 +185,79 @@ func (p *NeonProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		)
 	}
 
+	neonApiURL, err := neonApi.ParseNeonApiURL(neonApiURL)
+
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_url"),
+			"Invalid Neon API URL",
+			"The provider cannot create the Neon API client as the Neon API URL is invalid. "+
+				"Set the api_url value in the configuration or the NEON_API_HOST environment variable to an http or https URL such as "+neonApi.DefaultNeonApiURL+".\n\n"+
+				"Error: "+err.Error(),
+		)
+	}
+
+	retryPolicy := neonApi.DefaultNeonApiRetryPolicy()
+
+	if !config.MaxRetries.IsNull() {
+		if config.MaxRetries.Value < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid Neon API max retries",
+				fmt.Sprintf("The max_retries value must not be negative. given: %d", config.MaxRetries.Value),
+			)
+		}
+		retryPolicy.MaxRetries = int(config.MaxRetries.Value)
+	}
+
+	if !config.RetryMaxWait.IsNull() {
+		if config.RetryMaxWait.Value < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_max_wait"),
+				"Invalid Neon API retry max wait",
+				fmt.Sprintf("The retry_max_wait value must be at least 1 second. given: %d", config.RetryMaxWait.Value),
+			)
+		}
+		retryPolicy.MaxWait = time.Duration(config.RetryMaxWait.Value) * time.Second
+	}
+
+	throttle := neonApi.NeonApiThrottle{}
+
+	if !config.MaxConcurrentRequests.IsNull() {
+		if config.MaxConcurrentRequests.Value < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_concurrent_requests"),
+				"Invalid Neon API max concurrent requests",
+				fmt.Sprintf("The max_concurrent_requests value must be at least 1. given: %d", config.MaxConcurrentRequests.Value),
+			)
+		}
+		throttle.MaxConcurrentRequests = int(config.MaxConcurrentRequests.Value)
+	}
+
+	if !config.RequestsPerSecond.IsNull() {
+		if config.RequestsPerSecond.Value <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("requests_per_second"),
+				"Invalid Neon API requests per second",
+				fmt.Sprintf("The requests_per_second value must be greater than 0. given: %g", config.RequestsPerSecond.Value),
+			)
+		}
+		throttle.RequestsPerSecond = config.RequestsPerSecond.Value
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Create a new Neon client using the configuration values
 	client := neonApi.NewNeonApiClient(reqPkg.C(), neonApiKey)
+	client.SetApiURL(neonApiURL).SetRetryPolicy(retryPolicy).SetThrottle(throttle)
+
+	tflog.Debug(ctx, "Configured Neon API client.", map[string]interface{}{
+		"api_url":                 neonApiURL,
+		"max_concurrent_requests": throttle.MaxConcurrentRequests,
+		"requests_per_second":     throttle.RequestsPerSecond,
+	})
 
 	// Make the Neon client available during DataSource and Resource
 	// type Configure methods.
@@ -113,12 +268,22 @@ func (p *NeonProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 func (p *NeonProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewNeonBranchResource,
+		NewNeonDatabaseResource,
+		NewNeonEndpointResource,
 		NewNeonProjectResource,
+		NewNeonRoleResource,
 	}
 }
 
 func (p *NeonProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewNeonBranchDataSource,
+		NewNeonBranchesDataSource,
+		NewNeonConnectionURIDataSource,
+		NewNeonProjectDataSource,
+		NewNeonProjectsDataSource,
+		NewNeonRegionsDataSource,
+	}
 }
 
 func New(version string) func() provider.Provider {
@@ -1,11 +1,20 @@
 package provider
 
 import (
+	"context"
 	"os"
 	"testing"
+	"virtual-repetitions/terraform-provider-neon/src/neonApi"
+	"virtual-repetitions/terraform-provider-neon/src/neonApiTest"
 
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/imroc/req/v3"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -16,9 +25,117 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 	"neon": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+// testAccPreCheck runs acceptance tests against the real Neon API when NEON_API_KEY is set, and
+// against an in-memory fake of the Neon API otherwise.
 func testAccPreCheck(t *testing.T) {
 	_, hasKey := os.LookupEnv("NEON_API_KEY")
-	if !hasKey {
-		t.Fatalf("Need api key NEON_API_KEY")
+	if hasKey {
+		return
+	}
+
+	server := neonApiTest.NewServer()
+	t.Cleanup(server.Close)
+
+	t.Setenv("NEON_API_KEY", server.APIKey)
+	t.Setenv("NEON_API_HOST", server.URL)
+}
+
+// testNeonApiClient returns a client connected to a dedicated fake Neon API, for tests calling resource
+// methods directly without the Terraform CLI.
+func testNeonApiClient(t *testing.T) (neonApi.NeonApiClient, *neonApiTest.Server) {
+	server := neonApiTest.NewServer()
+	t.Cleanup(server.Close)
+
+	client := neonApi.NewNeonApiClient(req.C(), server.APIKey)
+	client.SetApiURL(server.URL)
+
+	return client, server
+}
+
+// testCreateProject creates a project in aws-us-west-2 with the given name.
+func testCreateProject(t *testing.T, client neonApi.NeonApiClient, name string) neonApi.NeonProjectMutationResult {
+	result, err := client.ProjectCreate(context.Background(), neonApi.NeonProjectCreateData{
+		Project: neonApi.NeonProjectCreateProjectAttributes{
+			InstanceHandle: "scalable",
+			Name:           name,
+			PlatformID:     "aws",
+			RegionID:       "aws-us-west-2",
+		},
+	}, neonApi.NeonApiClientOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result
+}
+
+// testResourceState builds the state of a resource from its model.
+func testResourceState(t *testing.T, r resource.Resource, model interface{}) tfsdk.State {
+	ctx := context.Background()
+
+	schema, diags := r.GetSchema(ctx)
+	testFailOnDiagnostics(t, diags)
+
+	state := tfsdk.State{
+		Schema: schema,
+		Raw:    tftypes.NewValue(schema.Type().TerraformType(ctx), nil),
+	}
+	testFailOnDiagnostics(t, state.Set(ctx, model))
+
+	return state
+}
+
+// testDataSourceRead reads a data source configured from its model.
+func testDataSourceRead(t *testing.T, d datasource.DataSource, model interface{}) datasource.ReadResponse {
+	ctx := context.Background()
+
+	schema, diags := d.GetSchema(ctx)
+	testFailOnDiagnostics(t, diags)
+
+	config := tfsdk.State{
+		Schema: schema,
+		Raw:    tftypes.NewValue(schema.Type().TerraformType(ctx), nil),
+	}
+	testFailOnDiagnostics(t, config.Set(ctx, model))
+
+	resp := datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schema,
+			Raw:    tftypes.NewValue(schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schema, Raw: config.Raw}}, &resp)
+
+	return resp
+}
+
+// testImportState imports a resource by the given ID and reads it, as Terraform does on import.
+func testImportState(t *testing.T, r resource.ResourceWithImportState, id string) (resource.ReadResponse, diag.Diagnostics) {
+	ctx := context.Background()
+
+	schema, diags := r.GetSchema(ctx)
+	testFailOnDiagnostics(t, diags)
+
+	importResp := resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schema,
+			Raw:    tftypes.NewValue(schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: id}, &importResp)
+
+	if importResp.Diagnostics.HasError() {
+		return resource.ReadResponse{}, importResp.Diagnostics
+	}
+
+	readResp := resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+
+	return readResp, readResp.Diagnostics
+}
+
+func testFailOnDiagnostics(t *testing.T, diags diag.Diagnostics) {
+	if diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
 	}
 }
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"virtual-repetitions/terraform-provider-neon/src/neonApi"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// regionIDRequiresReplace requires replacement when a region ID changes, unless both values name
+// the same region, such as `us-west-2` and `aws-us-west-2`.
+func regionIDRequiresReplace() tfsdk.AttributePlanModifier {
+	return resource.RequiresReplaceIf(
+		func(ctx context.Context, state, config attr.Value, path path.Path) (bool, diag.Diagnostics) {
+			stateRegionID, stateOk := state.(types.String)
+			configRegionID, configOk := config.(types.String)
+
+			if !stateOk || !configOk || stateRegionID.Null || stateRegionID.Unknown || configRegionID.Null || configRegionID.Unknown {
+				return true, nil
+			}
+
+			return !neonApi.EquivalentRegionIDs(stateRegionID.Value, configRegionID.Value), nil
+		},
+		"Changing the region creates a new resource. Region IDs with and without platform prefix are equivalent.",
+		"Changing the region creates a new resource. Region IDs with and without platform prefix, such as `us-west-2` and `aws-us-west-2`, are equivalent.",
+	)
+}
+
+// regionIDKeepsEquivalentState plans the prior region ID when the configured one names the same
+// region in another form, so switching between the forms is not planned as an update.
+func regionIDKeepsEquivalentState() tfsdk.AttributePlanModifier {
+	return regionIDKeepsEquivalentStateModifier{}
+}
+
+type regionIDKeepsEquivalentStateModifier struct{}
+
+func (m regionIDKeepsEquivalentStateModifier) Description(ctx context.Context) string {
+	return "Keeps the prior region ID when the configured one names the same region in another form."
+}
+
+func (m regionIDKeepsEquivalentStateModifier) MarkdownDescription(ctx context.Context) string {
+	return "Keeps the prior region ID when the configured one names the same region in another form, such as `us-west-2` and `aws-us-west-2`."
+}
+
+func (m regionIDKeepsEquivalentStateModifier) Modify(ctx context.Context, req tfsdk.ModifyAttributePlanRequest, resp *tfsdk.ModifyAttributePlanResponse) {
+	stateRegionID, stateOk := req.AttributeState.(types.String)
+	planRegionID, planOk := resp.AttributePlan.(types.String)
+
+	if !stateOk || !planOk || stateRegionID.Null || stateRegionID.Unknown || planRegionID.Null || planRegionID.Unknown {
+		return
+	}
+
+	if neonApi.EquivalentRegionIDs(stateRegionID.Value, planRegionID.Value) {
+		resp.AttributePlan = stateRegionID
+	}
+}
+
+// regionIDFromAPI returns the region ID reported by the API, keeping the prior value when it names
+// the same region in another form so the configured form does not show up as a difference.
+func regionIDFromAPI(prior types.String, regionID string) types.String {
+	if !prior.Null && !prior.Unknown && neonApi.EquivalentRegionIDs(prior.Value, regionID) {
+		return prior
+	}
+	return types.String{Value: regionID}
+}
+
+// validateRegionID adds an error to diags when a known region ID is not in the region catalog of
+// the client. Validation is skipped when the provider is not configured yet. When the regions cannot
+// be listed, regions missing from the built-in region list only get a warning, as that list is not
+// complete.
+func validateRegionID(ctx context.Context, client neonApi.NeonApiClient, attributePath path.Path, regionID types.String, diags *diag.Diagnostics) {
+	if client.Client == nil || regionID.Null || regionID.Unknown {
+		return
+	}
+
+	catalog, listErr := client.RegionCatalog(ctx)
+
+	if listErr != nil {
+		tflog.Debug(ctx, "Could not list Neon regions, validating against the built-in region list.", map[string]interface{}{"error": listErr.Error()})
+	}
+
+	if err := catalog.Validate(regionID.Value); err != nil {
+		if listErr != nil {
+			diags.AddAttributeWarning(
+				attributePath,
+				"Could not verify region",
+				"The regions could not be listed from the Neon API and the region is not in the built-in region list: "+err.Error(),
+			)
+			return
+		}
+
+		diags.AddAttributeError(attributePath, "Unknown region", err.Error())
+	}
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"virtual-repetitions/terraform-provider-neon/src/neonApiTest"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRegionIDRequiresReplace(t *testing.T) {
+	ctx := context.Background()
+	r := &NeonProjectResource{}
+
+	state := testResourceState(t, r, &neonProjectResourceModel{
+		RegionID: types.String{Value: "aws-us-west-2"},
+		Settings: types.Map{ElemType: types.StringType, Null: true},
+	})
+
+	for regionID, requiresReplace := range map[string]bool{"aws-us-west-2": false, "us-west-2": false, "aws-eu-central-1": true} {
+		planned := testResourceState(t, r, &neonProjectResourceModel{
+			RegionID: types.String{Value: regionID},
+			Settings: types.Map{ElemType: types.StringType, Null: true},
+		})
+
+		req := tfsdk.ModifyAttributePlanRequest{
+			AttributePath:   path.Root("region_id"),
+			AttributeConfig: types.String{Value: regionID},
+			AttributePlan:   types.String{Value: regionID},
+			AttributeState:  types.String{Value: "aws-us-west-2"},
+			Config:          tfsdk.Config{Schema: planned.Schema, Raw: planned.Raw},
+			Plan:            tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw},
+			State:           state,
+		}
+		resp := tfsdk.ModifyAttributePlanResponse{AttributePlan: req.AttributePlan}
+
+		regionIDRequiresReplace().Modify(ctx, req, &resp)
+		testFailOnDiagnostics(t, resp.Diagnostics)
+
+		if resp.RequiresReplace != requiresReplace {
+			t.Errorf("Expected changing aws-us-west-2 to %s to require replacement: %t", regionID, requiresReplace)
+		}
+	}
+}
+
+// TestRegionIDKeepsEquivalentState verifies switching between equivalent region forms plans no change
+func TestRegionIDKeepsEquivalentState(t *testing.T) {
+	ctx := context.Background()
+
+	for regionID, planned := range map[string]string{"aws-us-west-2": "aws-us-west-2", "us-west-2": "aws-us-west-2", "aws-eu-central-1": "aws-eu-central-1"} {
+		req := tfsdk.ModifyAttributePlanRequest{
+			AttributePath:   path.Root("region_id"),
+			AttributeConfig: types.String{Value: regionID},
+			AttributePlan:   types.String{Value: regionID},
+			AttributeState:  types.String{Value: "aws-us-west-2"},
+		}
+		resp := tfsdk.ModifyAttributePlanResponse{AttributePlan: req.AttributePlan}
+
+		regionIDKeepsEquivalentState().Modify(ctx, req, &resp)
+		testFailOnDiagnostics(t, resp.Diagnostics)
+
+		if plan := resp.AttributePlan.(types.String); plan.Value != planned {
+			t.Errorf("Expected changing aws-us-west-2 to %s to plan %s, got %s", regionID, planned, plan.Value)
+		}
+	}
+
+	req := tfsdk.ModifyAttributePlanRequest{
+		AttributePath:  path.Root("region_id"),
+		AttributePlan:  types.String{Value: "us-west-2"},
+		AttributeState: types.String{Null: true},
+	}
+	resp := tfsdk.ModifyAttributePlanResponse{AttributePlan: req.AttributePlan}
+
+	regionIDKeepsEquivalentState().Modify(ctx, req, &resp)
+
+	if plan := resp.AttributePlan.(types.String); plan.Value != "us-west-2" {
+		t.Errorf("Expected a new resource to plan the configured region, got %s", plan.Value)
+	}
+}
+
+func TestRegionIDFromAPI(t *testing.T) {
+	if regionID := regionIDFromAPI(types.String{Value: "us-west-2"}, "aws-us-west-2"); regionID.Value != "us-west-2" {
+		t.Errorf("Expected an equivalent prior region to be kept, got %s", regionID.Value)
+	}
+
+	if regionID := regionIDFromAPI(types.String{Value: "us-east-2"}, "aws-us-west-2"); regionID.Value != "aws-us-west-2" {
+		t.Errorf("Expected a different region to be taken from the API, got %s", regionID.Value)
+	}
+
+	if regionID := regionIDFromAPI(types.String{Null: true}, "aws-us-west-2"); regionID.Value != "aws-us-west-2" {
+		t.Errorf("Expected a missing region to be taken from the API, got %s", regionID.Value)
+	}
+}
+
+// TestValidateRegionID verifies unknown regions are only rejected when the regions could be listed
+func TestValidateRegionID(t *testing.T) {
+	ctx := context.Background()
+	client, server := testNeonApiClient(t)
+
+	server.Inject(neonApiTest.InjectedResponse{
+		Path:       "/api/v2/regions",
+		StatusCode: http.StatusNotFound,
+		Message:    "not found",
+		Times:      1,
+	})
+
+	var diags diag.Diagnostics
+	validateRegionID(ctx, client, path.Root("region_id"), types.String{Value: "aws-us-east-1"}, &diags)
+
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Errorf("Expected a single warning against the built-in region list, got %v", diags)
+	}
+
+	diags = nil
+	validateRegionID(ctx, client, path.Root("region_id"), types.String{Value: "aws-us-east-1"}, &diags)
+
+	if diags.ErrorsCount() != 1 {
+		t.Errorf("Expected an error once the regions are listed, got %v", diags)
+	}
+
+	diags = nil
+	validateRegionID(ctx, client, path.Root("region_id"), types.String{Value: "us-west-2"}, &diags)
+
+	if len(diags) != 0 {
+		t.Errorf("Expected a listed region to be accepted, got %v", diags)
+	}
+}
@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"virtual-repetitions/terraform-provider-neon/src/neonApi"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &NeonRegionsDataSource{}
+var _ datasource.DataSourceWithConfigure = &NeonRegionsDataSource{}
+
+func NewNeonRegionsDataSource() datasource.DataSource {
+	return &NeonRegionsDataSource{}
+}
+
+// NeonRegionsDataSource defines the data source implementation.
+type NeonRegionsDataSource struct {
+	client neonApi.NeonApiClient
+}
+
+// neonRegionsDataSourceModel describes the data source data model.
+type neonRegionsDataSourceModel struct {
+	ID      types.String      `tfsdk:"id"`
+	Regions []neonRegionModel `tfsdk:"regions"`
+}
+
+type neonRegionModel struct {
+	RegionID   types.String `tfsdk:"region_id"`
+	Name       types.String `tfsdk:"name"`
+	PlatformID types.String `tfsdk:"platform_id"`
+	Default    types.Bool   `tfsdk:"default"`
+}
+
+func (d *NeonRegionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_regions"
+}
+
+func (d *NeonRegionsDataSource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Regions Neon projects can be created in",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "Always `regions`.",
+				Type:                types.StringType,
+			},
+			"regions": {
+				Computed:            true,
+				MarkdownDescription: "Supported regions, in the order Neon lists them.",
+				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"region_id": {
+						Computed:            true,
+						MarkdownDescription: "Region ID, for example `aws-us-west-2`. Can be used as `region_id` of `neon_project`.",
+						Type:                types.StringType,
+					},
+					"name": {
+						Computed:            true,
+						MarkdownDescription: "Display name, for example `US West (Oregon)`.",
+						Type:                types.StringType,
+					},
+					"platform_id": {
+						Computed:            true,
+						MarkdownDescription: "Cloud platform of the region, for example `aws`. Can be used as `platform_id` of `neon_project`.",
+						Type:                types.StringType,
+					},
+					"default": {
+						Computed:            true,
+						MarkdownDescription: "Whether Neon creates projects in this region by default.",
+						Type:                types.BoolType,
+					},
+				}),
+			},
+		},
+	}, nil
+}
+
+func (d *NeonRegionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(neonApi.NeonApiClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected neonApi.NeonApiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *NeonRegionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	catalog, err := d.client.RegionCatalog(ctx)
+
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Could not list regions",
+			"The regions could not be listed from the Neon API, so the built-in region list is returned instead: "+err.Error(),
+		)
+	}
+
+	state := neonRegionsDataSourceModel{
+		ID:      types.String{Value: "regions"},
+		Regions: make([]neonRegionModel, 0, len(catalog.Regions)),
+	}
+
+	for _, region := range catalog.Regions {
+		state.Regions = append(state.Regions, neonRegionModel{
+			RegionID:   types.String{Value: region.RegionID},
+			Name:       types.String{Value: region.Name},
+			PlatformID: types.String{Value: region.PlatformID()},
+			Default:    types.Bool{Value: region.Default},
+		})
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
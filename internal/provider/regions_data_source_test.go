@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"virtual-repetitions/terraform-provider-neon/src/neonApi"
+	"virtual-repetitions/terraform-provider-neon/src/neonApiTest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNeonRegionsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNeonRegionsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.neon_regions.test", "id", "regions"),
+					resource.TestCheckResourceAttrSet("data.neon_regions.test", "regions.0.region_id"),
+					resource.TestCheckResourceAttrSet("data.neon_regions.test", "regions.0.name"),
+					resource.TestCheckResourceAttr("data.neon_regions.test", "regions.0.platform_id", "aws"),
+				),
+			},
+		},
+	})
+}
+
+func TestNeonRegionsDataSourceFallsBack(t *testing.T) {
+	ctx := context.Background()
+	client, server := testNeonApiClient(t)
+	d := &NeonRegionsDataSource{client: client}
+
+	server.Inject(neonApiTest.InjectedResponse{
+		Path:       "/api/v2/regions",
+		StatusCode: http.StatusNotFound,
+		Message:    "not found",
+	})
+
+	resp := testDataSourceRead(t, d, &neonRegionsDataSourceModel{})
+
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("Expected a single warning, got %v", resp.Diagnostics)
+	}
+
+	var state neonRegionsDataSourceModel
+	testFailOnDiagnostics(t, resp.State.Get(ctx, &state))
+
+	if len(state.Regions) != len(neonApi.NeonFallbackRegions) || state.Regions[0].RegionID.Value != neonApi.NeonFallbackRegions[0].RegionID {
+		t.Errorf("Expected the built-in regions, got %+v", state.Regions)
+	}
+}
+
+const testAccNeonRegionsDataSourceConfig = `
+provider "neon" { }
+data "neon_regions" "test" { }
+`
@@ -0,0 +1,302 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"virtual-repetitions/terraform-provider-neon/src/neonApi"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &NeonRoleResource{}
+var _ resource.ResourceWithImportState = &NeonRoleResource{}
+var _ resource.ResourceWithModifyPlan = &NeonRoleResource{}
+
+func NewNeonRoleResource() resource.Resource {
+	return &NeonRoleResource{}
+}
+
+// NeonRoleResource defines the resource implementation.
+type NeonRoleResource struct {
+	client neonApi.NeonApiClient
+}
+
+// neonRoleResourceModel describes the resource data model.
+type neonRoleResourceModel struct {
+	ID                   types.String `tfsdk:"id"`
+	ProjectID            types.String `tfsdk:"project_id"`
+	BranchID             types.String `tfsdk:"branch_id"`
+	Name                 types.String `tfsdk:"name"`
+	Password             types.String `tfsdk:"password"`
+	PasswordResetTrigger types.String `tfsdk:"password_reset_trigger"`
+}
+
+func (r *NeonRoleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role"
+}
+
+func (r *NeonRoleResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Neon Postgres role resource. The password is generated by Neon.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "Role ID of the form `project_id/branch_id/name`",
+				Type:                types.StringType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"project_id": {
+				Required:            true,
+				MarkdownDescription: "ID of the project the role belongs to.",
+				Type:                types.StringType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.RequiresReplace(),
+				},
+			},
+			"branch_id": {
+				Required:            true,
+				MarkdownDescription: "ID of the branch the role belongs to.",
+				Type:                types.StringType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.RequiresReplace(),
+				},
+			},
+			"name": {
+				Required:            true,
+				MarkdownDescription: "Role name.",
+				Type:                types.StringType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.RequiresReplace(),
+				},
+			},
+			"password": {
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Role password.",
+				Type:                types.StringType,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"password_reset_trigger": {
+				Optional:            true,
+				MarkdownDescription: "Arbitrary value that resets the password when changed, for example a rotation date.",
+				Type:                types.StringType,
+			},
+		},
+	}, nil
+}
+
+// ModifyPlan marks the password as unknown when password_reset_trigger changes, since the update replaces it.
+func (r *NeonRoleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var state, plan neonRoleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.PasswordResetTrigger.Equal(state.PasswordResetTrigger) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("password"), types.String{Unknown: true})...)
+	}
+}
+
+func (r *NeonRoleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(neonApi.NeonApiClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected neonApi.NeonApiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *NeonRoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan neonRoleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating Neon role resource.")
+
+	result, err := r.client.RoleCreate(ctx, plan.ProjectID.Value, plan.BranchID.Value, neonApi.NeonRoleCreateData{
+		Role: neonApi.NeonRoleCreateRoleAttributes{
+			Name: plan.Name.Value,
+		},
+	}, neonApi.NeonApiClientOptions{})
+
+	if err != nil && result.Role.Name != "" {
+		savePartiallyCreated(ctx, resp, newNeonRoleResourceModel(plan.ProjectID.Value, result.Role, plan))
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating role",
+			"Could not create role, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan = newNeonRoleResourceModel(plan.ProjectID.Value, result.Role, plan)
+
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *NeonRoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state neonRoleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role, err := r.client.RoleRead(ctx, state.ProjectID.Value, state.BranchID.Value, state.Name.Value, neonApi.NeonApiClientOptions{})
+
+	if neonApi.IsNotFound(err) {
+		resp.Diagnostics.AddWarning(
+			"Role not found",
+			fmt.Sprintf("Role %s no longer exists in Neon and has been removed from the Terraform state. It will be created again on the next apply.", state.ID.Value),
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading role",
+			"Could not read role, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// The API does not return passwords when reading roles. Reveal it when it is not known yet, for
+	// example after an import.
+	role.Password = state.Password.Value
+	if state.Password.Null || state.Password.Value == "" {
+		role.Password, err = r.client.RoleRevealPassword(ctx, state.ProjectID.Value, state.BranchID.Value, state.Name.Value, neonApi.NeonApiClientOptions{})
+
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading role",
+				"Could not reveal role password, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	state = newNeonRoleResourceModel(state.ProjectID.Value, role, state)
+
+	// Save updated state into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update resets the password. It is the only attribute that changes in place, through password_reset_trigger.
+func (r *NeonRoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state neonRoleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role := neonApi.NeonRole{BranchID: state.BranchID.Value, Name: state.Name.Value, Password: state.Password.Value}
+
+	if !plan.PasswordResetTrigger.Equal(state.PasswordResetTrigger) {
+		tflog.Debug(ctx, "Resetting Neon role password.", map[string]interface{}{"role": state.ID.Value})
+
+		result, err := r.client.RoleResetPassword(ctx, state.ProjectID.Value, state.BranchID.Value, state.Name.Value, neonApi.NeonApiClientOptions{})
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reset role password, got error: %s", err))
+			return
+		}
+
+		role = result.Role
+	}
+
+	plan = newNeonRoleResourceModel(plan.ProjectID.Value, role, plan)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *NeonRoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state neonRoleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.RoleDelete(ctx, state.ProjectID.Value, state.BranchID.Value, state.Name.Value, neonApi.NeonApiClientOptions{})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete role, got error: %s", err))
+		return
+	}
+}
+
+// ImportState imports a role by an ID of the form `project_id/branch_id/name`. Read reveals its password.
+func (r *NeonRoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, err := parseImportID(req.ID, "project_id", "branch_id", "name")
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("branch_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), parts[2])...)
+}
+
+func newNeonRoleResourceModel(projectID string, role neonApi.NeonRole, prior neonRoleResourceModel) neonRoleResourceModel {
+	return neonRoleResourceModel{
+		ID:                   types.String{Value: fmt.Sprintf("%s/%s/%s", projectID, role.BranchID, role.Name)},
+		ProjectID:            types.String{Value: projectID},
+		BranchID:             types.String{Value: role.BranchID},
+		Name:                 types.String{Value: role.Name},
+		Password:             types.String{Value: role.Password},
+		PasswordResetTrigger: prior.PasswordResetTrigger,
+	}
+}
@@ -1,60 +1,174 @@
 package neonApi
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
 
-type NeonBranchCreateResult struct {
-	Project  NeonProject
-	Response NeonBranchCreateSuccessResponse
-}
-
-type NeonBranchCreateSuccessResponse struct {
-	CreatedAt       time.Time         `json:"created_at"`
-	CurrentState    string            `json:"current_state"`
-	Deleted         bool              `json:"deleted"`
-	ID              string            `json:"id"`
-	InstanceHandle  string            `json:"instance_handle"`
-	InstanceTypeID  string            `json:"instance_type_id"`
-	MaxProjectSize  int               `json:"max_project_size"`
-	Name            string            `json:"name"`
-	ParentID        string            `json:"parent_id"`
-	PendingState    string            `json:"pending_state"`
-	PlatformID      string            `json:"platform_id"`
-	PlatformName    string            `json:"platform_name"`
-	PoolerEnabled   bool              `json:"pooler_enabled"`
-	RegionID        string            `json:"region_id"`
-	RegionName      string            `json:"region_name"`
-	Settings        map[string]string `json:"settings"`
-	AdditionalProp1 string            `json:"additionalProp1"`
-	AdditionalProp2 string            `json:"additionalProp2"`
-	AdditionalProp3 string            `json:"additionalProp3"`
-	Size            int               `json:"size"`
-	UpdatedAt       string            `json:"updated_at"`
-}
-
-func (client *NeonApiClient) BranchCreate(parentProjectID string, options NeonApiClientOptions) (NeonBranchCreateResult, error) {
-	var response NeonBranchCreateSuccessResponse
-
-	_, err := client.NewRequest().SetRetryCount(options.NumRetries).SetResult(&response).Post(fmt.Sprintf("/api/v1/projects/%s/branches", parentProjectID))
+type NeonBranch struct {
+	ID              string    `json:"id"`
+	ProjectID       string    `json:"project_id"`
+	ParentID        string    `json:"parent_id"`
+	ParentLsn       string    `json:"parent_lsn"`
+	ParentTimestamp string    `json:"parent_timestamp"`
+	Name            string    `json:"name"`
+	CurrentState    string    `json:"current_state"`
+	PendingState    string    `json:"pending_state"`
+	LogicalSize     int64     `json:"logical_size"`
+	Protected       bool      `json:"protected"`
+	Default         bool      `json:"default"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+type NeonBranchMutationResult struct {
+	Branch   NeonBranch
+	Response NeonBranchMutationSuccessResponse
+}
+
+type NeonBranchMutationSuccessResponse struct {
+	Branch     NeonBranch      `json:"branch"`
+	Endpoints  []NeonEndpoint  `json:"endpoints"`
+	Operations []NeonOperation `json:"operations"`
+}
+
+type NeonBranchReadSuccessResponse struct {
+	Branch NeonBranch `json:"branch"`
+}
+
+type NeonBranchListSuccessResponse struct {
+	Branches []NeonBranch `json:"branches"`
+}
+
+type NeonBranchCreateData struct {
+	Branch    NeonBranchCreateBranchAttributes     `json:"branch"`
+	Endpoints []NeonBranchCreateEndpointAttributes `json:"endpoints,omitempty"`
+}
+
+// NeonBranchCreateBranchAttributes describes a new branch. The branch is created from the project's
+// default branch when ParentID is empty, and from the head of its parent unless one of ParentLsn and
+// ParentTimestamp is given.
+type NeonBranchCreateBranchAttributes struct {
+	ParentID        string `json:"parent_id,omitempty"`
+	Name            string `json:"name,omitempty"`
+	ParentLsn       string `json:"parent_lsn,omitempty"`
+	ParentTimestamp string `json:"parent_timestamp,omitempty"`
+	Protected       bool   `json:"protected,omitempty"`
+}
+
+type NeonBranchCreateEndpointAttributes struct {
+	Type string `json:"type"`
+}
+
+type NeonBranchUpdateData struct {
+	Branch NeonBranchUpdateBranchAttributes `json:"branch"`
+}
+
+// NeonBranchUpdateBranchAttributes holds the branch attributes to change. Empty and nil attributes are left unchanged.
+type NeonBranchUpdateBranchAttributes struct {
+	Name      string `json:"name,omitempty"`
+	Protected *bool  `json:"protected,omitempty"`
+}
+
+func (client *NeonApiClient) BranchCreate(ctx context.Context, projectID string, data NeonBranchCreateData, options NeonApiClientOptions) (NeonBranchMutationResult, error) {
+	unlock, err := client.lockProject(ctx, projectID)
+	if err != nil {
+		return NeonBranchMutationResult{}, err
+	}
+	defer unlock()
+
+	var response NeonBranchMutationSuccessResponse
+
+	_, err = client.newRequest(ctx, options).SetResult(&response).SetBody(data).Post(fmt.Sprintf("/api/v2/projects/%s/branches", projectID))
+
+	if err != nil {
+		return NeonBranchMutationResult{}, err
+	}
+
+	result := NeonBranchMutationResult{
+		Branch:   response.Branch,
+		Response: response,
+	}
 
+	err = client.OperationsWait(ctx, projectID, response.Operations, options)
+
+	return result, err
+}
+
+// BranchRead returns the branch from the cached branches of the project, and reads it from the API when it
+// is not listed there. Errors listing the branches are returned, unless the project is not found.
+func (client *NeonApiClient) BranchRead(ctx context.Context, projectID string, branchID string, options NeonApiClientOptions) (NeonBranch, error) {
+	branches, err := client.BranchList(ctx, projectID, options)
+	if err != nil && !IsNotFound(err) {
+		return NeonBranch{}, err
+	}
+	if err == nil {
+		for _, branch := range branches {
+			if branch.ID == branchID {
+				return branch, nil
+			}
+		}
+	}
+
+	var response NeonBranchReadSuccessResponse
+
+	_, err = client.newRequest(ctx, options).SetResult(&response).Get(fmt.Sprintf("/api/v2/projects/%s/branches/%s", projectID, branchID))
+
+	return response.Branch, err
+}
+
+// BranchList lists the branches of the project, serving the list from the cache of the client for up to
+// readCacheMaxAge. The list is listed again after the project has been mutated through the client.
+func (client *NeonApiClient) BranchList(ctx context.Context, projectID string, options NeonApiClientOptions) ([]NeonBranch, error) {
+	return client.reads.branches.get(ctx, projectID, func() ([]NeonBranch, error) {
+		var response NeonBranchListSuccessResponse
+
+		_, err := client.newRequest(ctx, options).SetResult(&response).Get(fmt.Sprintf("/api/v2/projects/%s/branches", projectID))
+
+		return response.Branches, err
+	})
+}
+
+func (client *NeonApiClient) BranchUpdate(ctx context.Context, projectID string, branchID string, data NeonBranchUpdateData, options NeonApiClientOptions) (NeonBranchMutationResult, error) {
+	unlock, err := client.lockProject(ctx, projectID)
 	if err != nil {
-		return NeonBranchCreateResult{}, err
+		return NeonBranchMutationResult{}, err
 	}
+	defer unlock()
+
+	var response NeonBranchMutationSuccessResponse
 
-	project := NeonProject{
-		ID:             response.ID,
-		Name:           response.Name,
-		InstanceTypeID: response.InstanceTypeID,
-		ParentID:       response.ParentID,
-		PlatformID:     response.PlatformID,
-		RegionID:       response.RegionID,
-		Settings:       response.Settings,
+	_, err = client.newRequest(ctx, options).SetResult(&response).SetBody(data).Patch(fmt.Sprintf("/api/v2/projects/%s/branches/%s", projectID, branchID))
+
+	if err != nil {
+		return NeonBranchMutationResult{}, err
 	}
 
-	return NeonBranchCreateResult{
-		Project:  project,
+	result := NeonBranchMutationResult{
+		Branch:   response.Branch,
 		Response: response,
-	}, err
+	}
+
+	err = client.OperationsWait(ctx, projectID, response.Operations, options)
+
+	return result, err
+}
+
+func (client *NeonApiClient) BranchDelete(ctx context.Context, projectID string, branchID string, options NeonApiClientOptions) error {
+	unlock, err := client.lockProject(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	var response NeonBranchMutationSuccessResponse
+
+	_, err = client.newRequest(ctx, options).SetResult(&response).Delete(fmt.Sprintf("/api/v2/projects/%s/branches/%s", projectID, branchID))
+
+	if err != nil {
+		return err
+	}
+
+	return client.OperationsWait(ctx, projectID, response.Operations, options)
 }
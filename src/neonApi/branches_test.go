@@ -1,6 +1,7 @@
 package neonApi
 
 import (
+	"context"
 	"testing"
 )
 
@@ -10,16 +11,107 @@ func TestBranchCreate(t *testing.T) {
 
 	neonApiClient := NewNeonApiClientFixture()
 
-	result, err := neonApiClient.SetDebug(false).BranchCreate(projectFixture.ID, NewDefaultNeonApiClientOptionsFixture())
+	createData := NeonBranchCreateData{
+		Branch: NeonBranchCreateBranchAttributes{
+			Name: "test-branch",
+		},
+	}
+
+	result, err := neonApiClient.SetDebug(false).BranchCreate(context.Background(), projectFixture.ID, createData, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Error(err)
+	}
+
+	if result.Branch.ProjectID != projectFixture.ID {
+		t.Errorf("Expected project ID %s, got %s", projectFixture.ID, result.Branch.ProjectID)
+	}
+
+	if result.Branch.Name != createData.Branch.Name {
+		t.Errorf("Expected branch name %s, got %s", createData.Branch.Name, result.Branch.Name)
+	}
+
+	if result.Branch.ParentID == "" || result.Branch.Default {
+		t.Errorf("Expected branch to be created from the default branch, got %+v", result.Branch)
+	}
+}
+
+// TestBranchRead verifies Neon project branch can be read
+func TestBranchRead(t *testing.T) {
+	projectFixture := NewProjectFixture(t, true)
+	branchFixture := NewBranchFixture(t, projectFixture.ID)
+
+	neonApiClient := NewNeonApiClientFixture()
+
+	branch, err := neonApiClient.SetDebug(false).BranchRead(context.Background(), projectFixture.ID, branchFixture.ID, NewDefaultNeonApiClientOptionsFixture())
 	if err != nil {
 		t.Error(err)
 	}
 
-	if projectFixture.ID != result.Project.ParentID {
-		t.Errorf("Expected parent project ID %s, got %s", projectFixture.ID, result.Project.ParentID)
+	if branch.ID != branchFixture.ID || branch.Name != branchFixture.Name {
+		t.Errorf("Expected branch %+v, got %+v", branchFixture, branch)
+	}
+}
+
+// TestBranchList verifies the branches of a Neon project can be listed
+func TestBranchList(t *testing.T) {
+	projectFixture := NewProjectFixture(t, true)
+	branchFixture := NewBranchFixture(t, projectFixture.ID)
+
+	neonApiClient := NewNeonApiClientFixture()
+
+	branches, err := neonApiClient.SetDebug(false).BranchList(context.Background(), projectFixture.ID, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Error(err)
+	}
+
+	found := false
+	for _, branch := range branches {
+		if branch.ID == branchFixture.ID {
+			found = true
+		}
+	}
+
+	if !found {
+		t.Errorf("Expected branch %s to be listed, got %+v", branchFixture.ID, branches)
+	}
+}
+
+// TestBranchUpdate verifies Neon project branch can be renamed
+func TestBranchUpdate(t *testing.T) {
+	projectFixture := NewProjectFixture(t, true)
+	branchFixture := NewBranchFixture(t, projectFixture.ID)
+
+	neonApiClient := NewNeonApiClientFixture()
+
+	updateData := NeonBranchUpdateData{
+		Branch: NeonBranchUpdateBranchAttributes{
+			Name: "updated-branch-name",
+		},
+	}
+	result, err := neonApiClient.SetDebug(false).BranchUpdate(context.Background(), projectFixture.ID, branchFixture.ID, updateData, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Error(err)
+	}
+
+	if result.Branch.Name != updateData.Branch.Name {
+		t.Errorf("Expected branch name %s, got %s", updateData.Branch.Name, result.Branch.Name)
+	}
+}
+
+// TestBranchDelete verifies Neon project branch can be deleted
+func TestBranchDelete(t *testing.T) {
+	projectFixture := NewProjectFixture(t, true)
+	branchFixture := NewBranchFixture(t, projectFixture.ID)
+
+	neonApiClient := NewNeonApiClientFixture()
+
+	err := neonApiClient.SetDebug(false).BranchDelete(context.Background(), projectFixture.ID, branchFixture.ID, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Error(err)
 	}
 
-	if result.Project.ID != "" {
-		ProjectFixtureDelete(t, result.Project.ID)
+	_, err = neonApiClient.BranchRead(context.Background(), projectFixture.ID, branchFixture.ID, NewDefaultNeonApiClientOptionsFixture())
+	if !IsNotFound(err) {
+		t.Errorf("Expected deleted branch to be not found, got %v", err)
 	}
 }
@@ -1,45 +1,52 @@
 package neonApi
 
 import (
+	"context"
 	"fmt"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/imroc/req/v3"
 )
 
+// DefaultNeonApiURL is the base URL of the public Neon API.
+const DefaultNeonApiURL = "https://console.neon.tech/"
+
 type NeonApiClient struct {
 	*req.Client
+	retryPolicy NeonApiRetryPolicy
+	regions     *regionCatalogCache
+	redactor    redactor
+	logging     *requestLogging
+	projects    *projectLocks
+	throttle    *requestThrottle
+	reads       *projectReadCache
 }
 
 type NeonApiClientOptions struct {
+	// NumRetries overrides the retry count of the client's retry policy when greater than zero.
 	NumRetries int
 }
 
-type NeonApiErrorResponseBody struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
-}
-
-type NeonApiError struct {
-	Code     string
-	Message  string
-	Response *req.Response
-}
-
 type NeonApiRequestResult interface {
 	NeonProject
 }
 
 func NewNeonApiClient(httpClient *req.Client, authToken string) NeonApiClient {
+	redactor := newRedactor(authToken)
+	logging := &requestLogging{redactor: redactor}
+	throttle := &requestThrottle{logging: logging}
 
 	httpClient.
 		SetCommonHeader("Accept", "application/json").
 		SetCommonBearerAuthToken(authToken).
-		SetBaseURL("https://console.neon.tech/").
+		SetBaseURL(DefaultNeonApiURL).
 		// EnableDump at the request level in request middleware which dump content into
 		// memory (not print to stdout), we can record dump content only when unexpected
 		// exception occurs, it is helpful to troubleshoot problems in production.
 		OnBeforeRequest(func(c *req.Client, r *req.Request) error {
-			if r.RetryAttempt > 0 { // Ignore on retry.
+			if r.RetryAttempt > 0 && !logging.dumps.Load() { // Ignore on retry unless dumps are logged.
 				return nil
 			}
 			r.EnableDump()
@@ -59,28 +66,99 @@ func NewNeonApiClient(httpClient *req.Client, authToken string) NeonApiClient {
 			// Corner case: neither an error response nor a success response,
 			// dump content to help troubleshoot.
 			if !resp.IsSuccess() {
-				return fmt.Errorf("Neon API request failed. Bad response, raw dump:\n%s", resp.Dump())
+				return NeonApiError{
+					Message:  fmt.Sprintf("Bad response, raw dump:\n%s", redactor.redact(resp.Dump())),
+					Response: resp,
+				}
 			}
 			return nil
-		})
+		}).
+		// The last wrapper is the outermost. Requests are logged once the throttle let them through, so
+		// their logged duration is the time spent on the API only.
+		WrapRoundTripFunc(logging.logRequests, throttle.throttleRequests, wrapTransportErrors)
 
 	return NeonApiClient{
-		httpClient,
+		Client:      httpClient,
+		retryPolicy: DefaultNeonApiRetryPolicy(),
+		regions:     &regionCatalogCache{},
+		redactor:    redactor,
+		logging:     logging,
+		projects:    &projectLocks{},
+		throttle:    throttle,
+		reads:       &projectReadCache{},
 	}
 }
 
-func (e NeonApiError) Error() string {
-	return fmt.Sprintf("Neon API request failed. request_url: %s status_code: %d message: %s code: %s", e.Response.Request.URL.String(), e.Response.StatusCode, e.Message, e.Code)
+// newRequest returns a request bound to ctx that is retried according to the client's retry
+// policy. Cancelling ctx interrupts the request in flight and stops any further retries.
+func (client *NeonApiClient) newRequest(ctx context.Context, options NeonApiClientOptions) *req.Request {
+	policy := client.retryPolicy
+	if options.NumRetries > 0 {
+		policy.MaxRetries = options.NumRetries
+	}
+
+	retry := newRetryState(client.logging.newLogContext(ctx), policy)
+
+	return client.NewRequest().
+		SetContext(ctx).
+		SetRetryCount(policy.MaxRetries).
+		SetRetryInterval(func(resp *req.Response, attempt int) time.Duration {
+			return 0
+		}).
+		SetRetryCondition(retry.shouldRetry).
+		SetRetryHook(retry.waitBeforeRetry)
 }
 
-func (c *NeonApiClient) SetDebug(enable bool) *NeonApiClient {
-	if enable {
-		c.EnableDebugLog()
-		c.EnableDumpAll()
-	} else {
-		c.DisableDebugLog()
-		c.DisableDumpAll()
+// SetRetryPolicy replaces the retry policy used for all requests of the client.
+func (c *NeonApiClient) SetRetryPolicy(policy NeonApiRetryPolicy) *NeonApiClient {
+	c.retryPolicy = policy
+	return c
+}
+
+// SetThrottle limits the number of requests in flight and the rate at which requests are sent.
+func (c *NeonApiClient) SetThrottle(throttle NeonApiThrottle) *NeonApiClient {
+	c.throttle.set(throttle)
+	return c
+}
+
+// SetApiURL points the client at another Neon API, such as a staging environment or a local
+// stand-in. The URL should be validated with ParseNeonApiURL first.
+func (c *NeonApiClient) SetApiURL(apiURL string) *NeonApiClient {
+	c.SetBaseURL(apiURL)
+	return c
+}
+
+// ParseNeonApiURL validates a Neon API base URL and returns it in normalized form. A URL given
+// without a scheme, such as `console.neon.tech`, is assumed to use https.
+func ParseNeonApiURL(apiURL string) (string, error) {
+	if !strings.Contains(apiURL, "://") {
+		apiURL = "https://" + apiURL
+	}
+
+	parsed, err := url.Parse(apiURL)
+	if err != nil {
+		return "", fmt.Errorf("Could not parse Neon API URL. given: %s err: %s", apiURL, err)
+	}
+
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "", fmt.Errorf("Neon API URL must use http or https. given: %s", apiURL)
 	}
+
+	if parsed.Host == "" {
+		return "", fmt.Errorf("Neon API URL must include a host. given: %s", apiURL)
+	}
+
+	if parsed.RawQuery != "" || parsed.Fragment != "" || parsed.User != nil {
+		return "", fmt.Errorf("Neon API URL must not include credentials, a query or a fragment. given: %s", apiURL)
+	}
+
+	return parsed.String(), nil
+}
+
+// SetDebug records dumps of retried attempts in the trace logs of the neon_api subsystem. Dumps of first
+// attempts are always recorded.
+func (c *NeonApiClient) SetDebug(enable bool) *NeonApiClient {
+	c.logging.dumps.Store(enable)
 	return c
 }
 
@@ -0,0 +1,143 @@
+package neonApi
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+type NeonDatabase struct {
+	ID        int       `json:"id"`
+	BranchID  string    `json:"branch_id"`
+	Name      string    `json:"name"`
+	OwnerName string    `json:"owner_name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type NeonDatabaseMutationResult struct {
+	Database NeonDatabase
+	Response NeonDatabaseMutationSuccessResponse
+}
+
+type NeonDatabaseMutationSuccessResponse struct {
+	Database   NeonDatabase    `json:"database"`
+	Operations []NeonOperation `json:"operations"`
+}
+
+type NeonDatabaseReadSuccessResponse struct {
+	Database NeonDatabase `json:"database"`
+}
+
+type NeonDatabaseListSuccessResponse struct {
+	Databases []NeonDatabase `json:"databases"`
+}
+
+type NeonDatabaseCreateData struct {
+	Database NeonDatabaseCreateDatabaseAttributes `json:"database"`
+}
+
+type NeonDatabaseCreateDatabaseAttributes struct {
+	Name      string `json:"name"`
+	OwnerName string `json:"owner_name"`
+}
+
+type NeonDatabaseUpdateData struct {
+	Database NeonDatabaseUpdateDatabaseAttributes `json:"database"`
+}
+
+// NeonDatabaseUpdateDatabaseAttributes holds the database attributes to change. Empty attributes are left unchanged.
+type NeonDatabaseUpdateDatabaseAttributes struct {
+	Name      string `json:"name,omitempty"`
+	OwnerName string `json:"owner_name,omitempty"`
+}
+
+func databasePath(projectID string, branchID string, databaseName string) string {
+	return fmt.Sprintf("/api/v2/projects/%s/branches/%s/databases/%s", projectID, branchID, url.PathEscape(databaseName))
+}
+
+func (client *NeonApiClient) DatabaseCreate(ctx context.Context, projectID string, branchID string, data NeonDatabaseCreateData, options NeonApiClientOptions) (NeonDatabaseMutationResult, error) {
+	unlock, err := client.lockProject(ctx, projectID)
+	if err != nil {
+		return NeonDatabaseMutationResult{}, err
+	}
+	defer unlock()
+
+	var response NeonDatabaseMutationSuccessResponse
+
+	_, err = client.newRequest(ctx, options).SetResult(&response).SetBody(data).Post(fmt.Sprintf("/api/v2/projects/%s/branches/%s/databases", projectID, branchID))
+
+	if err != nil {
+		return NeonDatabaseMutationResult{}, err
+	}
+
+	result := NeonDatabaseMutationResult{
+		Database: response.Database,
+		Response: response,
+	}
+
+	err = client.OperationsWait(ctx, projectID, response.Operations, options)
+
+	return result, err
+}
+
+func (client *NeonApiClient) DatabaseRead(ctx context.Context, projectID string, branchID string, databaseName string, options NeonApiClientOptions) (NeonDatabase, error) {
+	var response NeonDatabaseReadSuccessResponse
+
+	_, err := client.newRequest(ctx, options).SetResult(&response).Get(databasePath(projectID, branchID, databaseName))
+
+	return response.Database, err
+}
+
+func (client *NeonApiClient) DatabaseList(ctx context.Context, projectID string, branchID string, options NeonApiClientOptions) ([]NeonDatabase, error) {
+	var response NeonDatabaseListSuccessResponse
+
+	_, err := client.newRequest(ctx, options).SetResult(&response).Get(fmt.Sprintf("/api/v2/projects/%s/branches/%s/databases", projectID, branchID))
+
+	return response.Databases, err
+}
+
+// DatabaseUpdate renames the database or changes its owner. The database is addressed by its current name.
+func (client *NeonApiClient) DatabaseUpdate(ctx context.Context, projectID string, branchID string, databaseName string, data NeonDatabaseUpdateData, options NeonApiClientOptions) (NeonDatabaseMutationResult, error) {
+	unlock, err := client.lockProject(ctx, projectID)
+	if err != nil {
+		return NeonDatabaseMutationResult{}, err
+	}
+	defer unlock()
+
+	var response NeonDatabaseMutationSuccessResponse
+
+	_, err = client.newRequest(ctx, options).SetResult(&response).SetBody(data).Patch(databasePath(projectID, branchID, databaseName))
+
+	if err != nil {
+		return NeonDatabaseMutationResult{}, err
+	}
+
+	result := NeonDatabaseMutationResult{
+		Database: response.Database,
+		Response: response,
+	}
+
+	err = client.OperationsWait(ctx, projectID, response.Operations, options)
+
+	return result, err
+}
+
+func (client *NeonApiClient) DatabaseDelete(ctx context.Context, projectID string, branchID string, databaseName string, options NeonApiClientOptions) error {
+	unlock, err := client.lockProject(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	var response NeonDatabaseMutationSuccessResponse
+
+	_, err = client.newRequest(ctx, options).SetResult(&response).Delete(databasePath(projectID, branchID, databaseName))
+
+	if err != nil {
+		return err
+	}
+
+	return client.OperationsWait(ctx, projectID, response.Operations, options)
+}
@@ -0,0 +1,119 @@
+package neonApi
+
+import (
+	"context"
+	"testing"
+)
+
+func newDatabaseFixture(t *testing.T, projectID string, branchID string, ownerName string) NeonDatabase {
+	neonApiClient := NewNeonApiClientFixture()
+
+	result, err := neonApiClient.DatabaseCreate(context.Background(), projectID, branchID, NeonDatabaseCreateData{
+		Database: NeonDatabaseCreateDatabaseAttributes{Name: "test_database", OwnerName: ownerName},
+	}, NewDefaultNeonApiClientOptionsFixture())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result.Database
+}
+
+// TestDatabaseCreate verifies Neon database can be created
+func TestDatabaseCreate(t *testing.T) {
+	projectFixture := NewProjectFixture(t, true)
+	branchFixture := NewBranchFixture(t, projectFixture.ID)
+	roleFixture := newRoleFixture(t, projectFixture.ID, branchFixture.ID)
+
+	neonApiClient := NewNeonApiClientFixture()
+
+	createData := NeonDatabaseCreateData{
+		Database: NeonDatabaseCreateDatabaseAttributes{Name: "app", OwnerName: roleFixture.Name},
+	}
+
+	result, err := neonApiClient.SetDebug(false).DatabaseCreate(context.Background(), projectFixture.ID, branchFixture.ID, createData, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Error(err)
+	}
+
+	if result.Database.Name != createData.Database.Name || result.Database.OwnerName != createData.Database.OwnerName {
+		t.Errorf("Expected fields to be set during creation. expected field values of %+v, got %+v", createData, result.Database)
+	}
+}
+
+// TestDatabaseReadAndList verifies Neon database can be read and listed
+func TestDatabaseReadAndList(t *testing.T) {
+	projectFixture := NewProjectFixture(t, true)
+	branchFixture := NewBranchFixture(t, projectFixture.ID)
+	roleFixture := newRoleFixture(t, projectFixture.ID, branchFixture.ID)
+	databaseFixture := newDatabaseFixture(t, projectFixture.ID, branchFixture.ID, roleFixture.Name)
+
+	neonApiClient := NewNeonApiClientFixture()
+
+	database, err := neonApiClient.SetDebug(false).DatabaseRead(context.Background(), projectFixture.ID, branchFixture.ID, databaseFixture.Name, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Error(err)
+	}
+
+	if database.ID != databaseFixture.ID {
+		t.Errorf("Expected database ID %d, got %d", databaseFixture.ID, database.ID)
+	}
+
+	databases, err := neonApiClient.DatabaseList(context.Background(), projectFixture.ID, branchFixture.ID, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Error(err)
+	}
+
+	found := false
+	for _, database := range databases {
+		if database.Name == databaseFixture.Name {
+			found = true
+		}
+	}
+
+	if !found {
+		t.Errorf("Expected database %s to be listed, got %+v", databaseFixture.Name, databases)
+	}
+}
+
+// TestDatabaseUpdate verifies Neon database can be renamed and change owner
+func TestDatabaseUpdate(t *testing.T) {
+	projectFixture := NewProjectFixture(t, true)
+	branchFixture := NewBranchFixture(t, projectFixture.ID)
+	roleFixture := newRoleFixture(t, projectFixture.ID, branchFixture.ID)
+	databaseFixture := newDatabaseFixture(t, projectFixture.ID, branchFixture.ID, roleFixture.Name)
+
+	neonApiClient := NewNeonApiClientFixture()
+
+	updateData := NeonDatabaseUpdateData{
+		Database: NeonDatabaseUpdateDatabaseAttributes{Name: "renamed_database", OwnerName: "neondb_owner"},
+	}
+	result, err := neonApiClient.SetDebug(false).DatabaseUpdate(context.Background(), projectFixture.ID, branchFixture.ID, databaseFixture.Name, updateData, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Error(err)
+	}
+
+	if result.Database.Name != updateData.Database.Name || result.Database.OwnerName != updateData.Database.OwnerName {
+		t.Errorf("Expected database to be updated to %+v, got %+v", updateData, result.Database)
+	}
+}
+
+// TestDatabaseDelete verifies Neon database can be deleted
+func TestDatabaseDelete(t *testing.T) {
+	projectFixture := NewProjectFixture(t, true)
+	branchFixture := NewBranchFixture(t, projectFixture.ID)
+	roleFixture := newRoleFixture(t, projectFixture.ID, branchFixture.ID)
+	databaseFixture := newDatabaseFixture(t, projectFixture.ID, branchFixture.ID, roleFixture.Name)
+
+	neonApiClient := NewNeonApiClientFixture()
+
+	err := neonApiClient.SetDebug(false).DatabaseDelete(context.Background(), projectFixture.ID, branchFixture.ID, databaseFixture.Name, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = neonApiClient.DatabaseRead(context.Background(), projectFixture.ID, branchFixture.ID, databaseFixture.Name, NewDefaultNeonApiClientOptionsFixture())
+	if !IsNotFound(err) {
+		t.Errorf("Expected deleted database to be not found, got %v", err)
+	}
+}
@@ -0,0 +1,181 @@
+package neonApi
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Endpoint types reported by the Neon API. A branch has at most one read_write endpoint.
+const (
+	NeonEndpointTypeReadWrite = "read_write"
+	NeonEndpointTypeReadOnly  = "read_only"
+)
+
+type NeonEndpoint struct {
+	ID                    string    `json:"id"`
+	ProjectID             string    `json:"project_id"`
+	BranchID              string    `json:"branch_id"`
+	Host                  string    `json:"host"`
+	RegionID              string    `json:"region_id"`
+	Type                  string    `json:"type"`
+	CurrentState          string    `json:"current_state"`
+	AutoscalingLimitMinCu float64   `json:"autoscaling_limit_min_cu"`
+	AutoscalingLimitMaxCu float64   `json:"autoscaling_limit_max_cu"`
+	SuspendTimeoutSeconds int64     `json:"suspend_timeout_seconds"`
+	PoolerEnabled         bool      `json:"pooler_enabled"`
+	PoolerMode            string    `json:"pooler_mode"`
+	Disabled              bool      `json:"disabled"`
+	CreatedAt             time.Time `json:"created_at"`
+	UpdatedAt             time.Time `json:"updated_at"`
+}
+
+type NeonEndpointMutationResult struct {
+	Endpoint NeonEndpoint
+	Response NeonEndpointMutationSuccessResponse
+}
+
+type NeonEndpointMutationSuccessResponse struct {
+	Endpoint   NeonEndpoint    `json:"endpoint"`
+	Operations []NeonOperation `json:"operations"`
+}
+
+type NeonEndpointReadSuccessResponse struct {
+	Endpoint NeonEndpoint `json:"endpoint"`
+}
+
+type NeonEndpointListSuccessResponse struct {
+	Endpoints []NeonEndpoint `json:"endpoints"`
+}
+
+type NeonEndpointCreateData struct {
+	Endpoint NeonEndpointCreateEndpointAttributes `json:"endpoint"`
+}
+
+// NeonEndpointCreateEndpointAttributes describes a new endpoint. Zero values are left for the API to default.
+type NeonEndpointCreateEndpointAttributes struct {
+	BranchID              string  `json:"branch_id"`
+	Type                  string  `json:"type"`
+	RegionID              string  `json:"region_id,omitempty"`
+	AutoscalingLimitMinCu float64 `json:"autoscaling_limit_min_cu,omitempty"`
+	AutoscalingLimitMaxCu float64 `json:"autoscaling_limit_max_cu,omitempty"`
+	SuspendTimeoutSeconds int64   `json:"suspend_timeout_seconds,omitempty"`
+	PoolerEnabled         bool    `json:"pooler_enabled,omitempty"`
+	PoolerMode            string  `json:"pooler_mode,omitempty"`
+}
+
+type NeonEndpointUpdateData struct {
+	Endpoint NeonEndpointUpdateEndpointAttributes `json:"endpoint"`
+}
+
+// NeonEndpointUpdateEndpointAttributes holds the endpoint attributes to change. Empty and nil attributes are left unchanged.
+type NeonEndpointUpdateEndpointAttributes struct {
+	BranchID              string   `json:"branch_id,omitempty"`
+	AutoscalingLimitMinCu *float64 `json:"autoscaling_limit_min_cu,omitempty"`
+	AutoscalingLimitMaxCu *float64 `json:"autoscaling_limit_max_cu,omitempty"`
+	SuspendTimeoutSeconds *int64   `json:"suspend_timeout_seconds,omitempty"`
+	PoolerEnabled         *bool    `json:"pooler_enabled,omitempty"`
+	PoolerMode            string   `json:"pooler_mode,omitempty"`
+}
+
+func (client *NeonApiClient) EndpointCreate(ctx context.Context, projectID string, data NeonEndpointCreateData, options NeonApiClientOptions) (NeonEndpointMutationResult, error) {
+	unlock, err := client.lockProject(ctx, projectID)
+	if err != nil {
+		return NeonEndpointMutationResult{}, err
+	}
+	defer unlock()
+
+	var response NeonEndpointMutationSuccessResponse
+
+	_, err = client.newRequest(ctx, options).SetResult(&response).SetBody(data).Post(fmt.Sprintf("/api/v2/projects/%s/endpoints", projectID))
+
+	if err != nil {
+		return NeonEndpointMutationResult{}, err
+	}
+
+	result := NeonEndpointMutationResult{
+		Endpoint: response.Endpoint,
+		Response: response,
+	}
+
+	err = client.OperationsWait(ctx, projectID, response.Operations, options)
+
+	return result, err
+}
+
+// EndpointRead returns the endpoint from the cached endpoints of the project, and reads it from the API when it
+// is not listed there. Errors listing the endpoints are returned, unless the project is not found.
+func (client *NeonApiClient) EndpointRead(ctx context.Context, projectID string, endpointID string, options NeonApiClientOptions) (NeonEndpoint, error) {
+	endpoints, err := client.EndpointList(ctx, projectID, options)
+	if err != nil && !IsNotFound(err) {
+		return NeonEndpoint{}, err
+	}
+	if err == nil {
+		for _, endpoint := range endpoints {
+			if endpoint.ID == endpointID {
+				return endpoint, nil
+			}
+		}
+	}
+
+	var response NeonEndpointReadSuccessResponse
+
+	_, err = client.newRequest(ctx, options).SetResult(&response).Get(fmt.Sprintf("/api/v2/projects/%s/endpoints/%s", projectID, endpointID))
+
+	return response.Endpoint, err
+}
+
+// EndpointList lists the endpoints of the project, serving the list from the cache of the client for up to
+// readCacheMaxAge. The list is listed again after the project has been mutated through the client.
+func (client *NeonApiClient) EndpointList(ctx context.Context, projectID string, options NeonApiClientOptions) ([]NeonEndpoint, error) {
+	return client.reads.endpoints.get(ctx, projectID, func() ([]NeonEndpoint, error) {
+		var response NeonEndpointListSuccessResponse
+
+		_, err := client.newRequest(ctx, options).SetResult(&response).Get(fmt.Sprintf("/api/v2/projects/%s/endpoints", projectID))
+
+		return response.Endpoints, err
+	})
+}
+
+func (client *NeonApiClient) EndpointUpdate(ctx context.Context, projectID string, endpointID string, data NeonEndpointUpdateData, options NeonApiClientOptions) (NeonEndpointMutationResult, error) {
+	unlock, err := client.lockProject(ctx, projectID)
+	if err != nil {
+		return NeonEndpointMutationResult{}, err
+	}
+	defer unlock()
+
+	var response NeonEndpointMutationSuccessResponse
+
+	_, err = client.newRequest(ctx, options).SetResult(&response).SetBody(data).Patch(fmt.Sprintf("/api/v2/projects/%s/endpoints/%s", projectID, endpointID))
+
+	if err != nil {
+		return NeonEndpointMutationResult{}, err
+	}
+
+	result := NeonEndpointMutationResult{
+		Endpoint: response.Endpoint,
+		Response: response,
+	}
+
+	err = client.OperationsWait(ctx, projectID, response.Operations, options)
+
+	return result, err
+}
+
+func (client *NeonApiClient) EndpointDelete(ctx context.Context, projectID string, endpointID string, options NeonApiClientOptions) error {
+	unlock, err := client.lockProject(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	var response NeonEndpointMutationSuccessResponse
+
+	_, err = client.newRequest(ctx, options).SetResult(&response).Delete(fmt.Sprintf("/api/v2/projects/%s/endpoints/%s", projectID, endpointID))
+
+	if err != nil {
+		return err
+	}
+
+	return client.OperationsWait(ctx, projectID, response.Operations, options)
+}
@@ -0,0 +1,136 @@
+package neonApi
+
+import (
+	"context"
+	"testing"
+)
+
+func newEndpointFixture(t *testing.T, projectID string, branchID string) NeonEndpoint {
+	neonApiClient := NewNeonApiClientFixture()
+
+	result, err := neonApiClient.EndpointCreate(context.Background(), projectID, NeonEndpointCreateData{
+		Endpoint: NeonEndpointCreateEndpointAttributes{
+			BranchID: branchID,
+			Type:     NeonEndpointTypeReadWrite,
+		},
+	}, NewDefaultNeonApiClientOptionsFixture())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result.Endpoint
+}
+
+// TestEndpointCreate verifies Neon compute endpoint can be created
+func TestEndpointCreate(t *testing.T) {
+	projectFixture := NewProjectFixture(t, true)
+	branchFixture := NewBranchFixture(t, projectFixture.ID)
+
+	neonApiClient := NewNeonApiClientFixture()
+
+	createData := NeonEndpointCreateData{
+		Endpoint: NeonEndpointCreateEndpointAttributes{
+			BranchID:              branchFixture.ID,
+			Type:                  NeonEndpointTypeReadWrite,
+			AutoscalingLimitMinCu: 0.25,
+			AutoscalingLimitMaxCu: 2,
+			SuspendTimeoutSeconds: 300,
+		},
+	}
+
+	result, err := neonApiClient.SetDebug(false).EndpointCreate(context.Background(), projectFixture.ID, createData, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Error(err)
+	}
+
+	if result.Endpoint.BranchID != branchFixture.ID || result.Endpoint.Host == "" {
+		t.Errorf("Expected endpoint of branch %s with a host, got %+v", branchFixture.ID, result.Endpoint)
+	}
+
+	if result.Endpoint.AutoscalingLimitMaxCu != createData.Endpoint.AutoscalingLimitMaxCu || result.Endpoint.SuspendTimeoutSeconds != createData.Endpoint.SuspendTimeoutSeconds {
+		t.Errorf("Expected fields to be set during creation. expected field values of %+v, got %+v", createData, result.Endpoint)
+	}
+}
+
+// TestEndpointReadAndList verifies Neon compute endpoint can be read and listed
+func TestEndpointReadAndList(t *testing.T) {
+	projectFixture := NewProjectFixture(t, true)
+	branchFixture := NewBranchFixture(t, projectFixture.ID)
+	endpointFixture := newEndpointFixture(t, projectFixture.ID, branchFixture.ID)
+
+	neonApiClient := NewNeonApiClientFixture()
+
+	endpoint, err := neonApiClient.SetDebug(false).EndpointRead(context.Background(), projectFixture.ID, endpointFixture.ID, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Error(err)
+	}
+
+	if endpoint.ID != endpointFixture.ID {
+		t.Errorf("Expected endpoint ID %s, got %s", endpointFixture.ID, endpoint.ID)
+	}
+
+	endpoints, err := neonApiClient.EndpointList(context.Background(), projectFixture.ID, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Error(err)
+	}
+
+	found := false
+	for _, endpoint := range endpoints {
+		if endpoint.ID == endpointFixture.ID {
+			found = true
+		}
+	}
+
+	if !found {
+		t.Errorf("Expected endpoint %s to be listed, got %+v", endpointFixture.ID, endpoints)
+	}
+}
+
+// TestEndpointUpdate verifies Neon compute endpoint settings can be changed
+func TestEndpointUpdate(t *testing.T) {
+	projectFixture := NewProjectFixture(t, true)
+	branchFixture := NewBranchFixture(t, projectFixture.ID)
+	endpointFixture := newEndpointFixture(t, projectFixture.ID, branchFixture.ID)
+
+	neonApiClient := NewNeonApiClientFixture()
+
+	maxCu := 4.0
+	poolerEnabled := true
+	result, err := neonApiClient.SetDebug(false).EndpointUpdate(context.Background(), projectFixture.ID, endpointFixture.ID, NeonEndpointUpdateData{
+		Endpoint: NeonEndpointUpdateEndpointAttributes{
+			AutoscalingLimitMaxCu: &maxCu,
+			PoolerEnabled:         &poolerEnabled,
+		},
+	}, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Error(err)
+	}
+
+	if result.Endpoint.AutoscalingLimitMaxCu != maxCu || !result.Endpoint.PoolerEnabled {
+		t.Errorf("Expected endpoint to be updated, got %+v", result.Endpoint)
+	}
+
+	if result.Endpoint.AutoscalingLimitMinCu != endpointFixture.AutoscalingLimitMinCu {
+		t.Errorf("Expected unset fields to be unchanged, got %+v", result.Endpoint)
+	}
+}
+
+// TestEndpointDelete verifies Neon compute endpoint can be deleted
+func TestEndpointDelete(t *testing.T) {
+	projectFixture := NewProjectFixture(t, true)
+	branchFixture := NewBranchFixture(t, projectFixture.ID)
+	endpointFixture := newEndpointFixture(t, projectFixture.ID, branchFixture.ID)
+
+	neonApiClient := NewNeonApiClientFixture()
+
+	err := neonApiClient.SetDebug(false).EndpointDelete(context.Background(), projectFixture.ID, endpointFixture.ID, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = neonApiClient.EndpointRead(context.Background(), projectFixture.ID, endpointFixture.ID, NewDefaultNeonApiClientOptionsFixture())
+	if !IsNotFound(err) {
+		t.Errorf("Expected deleted endpoint to be not found, got %v", err)
+	}
+}
@@ -0,0 +1,149 @@
+package neonApi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/imroc/req/v3"
+)
+
+// Sentinel errors matched by NeonApiError through errors.Is, for example:
+//
+//	if errors.Is(err, neonApi.ErrNotFound) { ... }
+var (
+	ErrNotFound      = errors.New("Neon API object not found")
+	ErrConflict      = errors.New("Neon API request conflicts with an existing object")
+	ErrLocked        = errors.New("Neon project has running operations")
+	ErrRateLimited   = errors.New("Neon API rate limit exceeded")
+	ErrUnauthorized  = errors.New("Neon API key is missing or invalid")
+	ErrForbidden     = errors.New("Neon API key is not allowed to perform the request")
+	ErrQuotaExceeded = errors.New("Neon plan quota exceeded")
+)
+
+type NeonApiErrorResponseBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type NeonApiError struct {
+	Code     string
+	Message  string
+	Response *req.Response
+}
+
+// NeonApiTransportError is returned when a request could not be completed, for example because the
+// connection failed or the context was cancelled. The underlying error is available through errors.Unwrap.
+type NeonApiTransportError struct {
+	Method string
+	URL    string
+	Err    error
+}
+
+// Error describes the failed request. Secrets echoed by the API, for example in a raw dump, are redacted.
+func (e NeonApiError) Error() string {
+	return Redact(fmt.Sprintf("Neon API request failed. request_url: %s status_code: %d message: %s code: %s", e.requestURL(), e.StatusCode(), e.Message, e.Code))
+}
+
+// requestURL returns the URL of the failed request, or an empty string when the error has no request.
+func (e NeonApiError) requestURL() string {
+	if e.Response == nil || e.Response.Request == nil || e.Response.Request.URL == nil {
+		return ""
+	}
+	return e.Response.Request.URL.String()
+}
+
+// StatusCode returns the HTTP status code of the failed response.
+func (e NeonApiError) StatusCode() int {
+	if e.Response == nil || e.Response.Response == nil {
+		return 0
+	}
+	return e.Response.StatusCode
+}
+
+// Is matches the sentinel errors of this package against the status code and error code of the response.
+func (e NeonApiError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode() == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode() == http.StatusConflict
+	case ErrLocked:
+		return e.StatusCode() == http.StatusLocked
+	case ErrRateLimited:
+		return e.StatusCode() == http.StatusTooManyRequests
+	case ErrUnauthorized:
+		return e.StatusCode() == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode() == http.StatusForbidden
+	case ErrQuotaExceeded:
+		// Neon reports exhausted plan limits with codes such as `PROJECTS_LIMIT_EXCEEDED`.
+		code := strings.ToUpper(e.Code)
+		return e.StatusCode() == http.StatusPaymentRequired || strings.HasSuffix(code, "_LIMIT_EXCEEDED") || strings.Contains(code, "QUOTA")
+	}
+	return false
+}
+
+func (e NeonApiTransportError) Error() string {
+	return Redact(fmt.Sprintf("Neon API request failed. method: %s request_url: %s err: %s", e.Method, e.URL, e.Err))
+}
+
+func (e NeonApiTransportError) Unwrap() error {
+	return e.Err
+}
+
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+func IsLocked(err error) bool {
+	return errors.Is(err, ErrLocked)
+}
+
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
+
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrForbidden)
+}
+
+func IsQuotaExceeded(err error) bool {
+	return errors.Is(err, ErrQuotaExceeded)
+}
+
+// IsTransportError reports whether the request failed before a response was received.
+func IsTransportError(err error) bool {
+	var transportErr NeonApiTransportError
+	return errors.As(err, &transportErr)
+}
+
+// wrapTransportErrors is a round trip middleware converting errors of requests that did not get a
+// response into NeonApiTransportError.
+func wrapTransportErrors(rt req.RoundTripper) req.RoundTripFunc {
+	return func(r *req.Request) (*req.Response, error) {
+		resp, err := rt.RoundTrip(r)
+
+		if err != nil && (resp == nil || resp.Response == nil) {
+			url := r.RawURL
+			if r.URL != nil {
+				url = r.URL.String()
+			}
+			err = NeonApiTransportError{Method: r.Method, URL: url, Err: err}
+			if resp != nil {
+				resp.Err = err
+			}
+		}
+
+		return resp, err
+	}
+}
@@ -0,0 +1,93 @@
+package neonApi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"virtual-repetitions/terraform-provider-neon/src/neonApiTest"
+
+	"github.com/imroc/req/v3"
+)
+
+// TestErrorPredicates verifies error responses are classified by status code and error code
+func TestErrorPredicates(t *testing.T) {
+	cases := []struct {
+		statusCode int
+		code       string
+		predicate  func(error) bool
+		sentinel   error
+	}{
+		{http.StatusNotFound, "", IsNotFound, ErrNotFound},
+		{http.StatusConflict, "", IsConflict, ErrConflict},
+		{http.StatusLocked, "", IsLocked, ErrLocked},
+		{http.StatusTooManyRequests, "", IsRateLimited, ErrRateLimited},
+		{http.StatusUnauthorized, "", IsUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, "", IsForbidden, ErrForbidden},
+		{http.StatusUnprocessableEntity, "BRANCHES_LIMIT_EXCEEDED", IsQuotaExceeded, ErrQuotaExceeded},
+	}
+
+	for _, c := range cases {
+		neonApiClient, server := NewFakeNeonApiClientFixture(t)
+		neonApiClient.SetRetryPolicy(NeonApiRetryPolicy{})
+
+		server.Inject(neonApiTest.InjectedResponse{
+			StatusCode: c.statusCode,
+			Code:       c.code,
+			Message:    http.StatusText(c.statusCode),
+		})
+
+		_, err := neonApiClient.ProjectRead(context.Background(), "some-project", NewDefaultNeonApiClientOptionsFixture())
+
+		if !c.predicate(err) || !errors.Is(err, c.sentinel) {
+			t.Errorf("Expected status %d code %q to match %s, got %v", c.statusCode, c.code, c.sentinel, err)
+		}
+
+		if IsNotFound(err) != (c.sentinel == ErrNotFound) {
+			t.Errorf("Expected status %d not to be classified as not found", c.statusCode)
+		}
+	}
+}
+
+// TestErrorWithoutResponse verifies errors built without a response can be formatted and matched
+func TestErrorWithoutResponse(t *testing.T) {
+	for _, err := range []NeonApiError{
+		{Code: "BRANCHES_LIMIT_EXCEEDED", Message: "too many branches"},
+		{Message: "no request", Response: &req.Response{}},
+	} {
+		if message := err.Error(); !strings.Contains(message, err.Message) || !strings.Contains(message, "status_code: 0") {
+			t.Errorf("Expected the message without request details, got %s", message)
+		}
+		if IsNotFound(err) {
+			t.Errorf("Expected an error without response not to match %v", ErrNotFound)
+		}
+	}
+}
+
+// TestTransportErrorsAreWrapped verifies failed connections are returned as NeonApiTransportError
+func TestTransportErrorsAreWrapped(t *testing.T) {
+	neonApiClient, server := NewFakeNeonApiClientFixture(t)
+	server.Close()
+
+	_, err := neonApiClient.ProjectRead(context.Background(), "some-project", NewDefaultNeonApiClientOptionsFixture())
+
+	var transportErr NeonApiTransportError
+	if !errors.As(err, &transportErr) || !IsTransportError(err) {
+		t.Fatalf("Expected NeonApiTransportError, got %v", err)
+	}
+
+	if transportErr.Method != http.MethodGet {
+		t.Errorf("Expected method to be recorded, got %s", transportErr.Method)
+	}
+
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		t.Errorf("Expected underlying error to be unwrapped, got %v", err)
+	}
+
+	if IsNotFound(err) {
+		t.Errorf("Expected transport error not to be classified as not found")
+	}
+}
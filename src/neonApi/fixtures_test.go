@@ -0,0 +1,109 @@
+package neonApi
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"sync"
+	"testing"
+	"time"
+	"virtual-repetitions/terraform-provider-neon/src/neonApiTest"
+
+	"github.com/imroc/req/v3"
+)
+
+var (
+	sharedFakeServer     *neonApiTest.Server
+	sharedFakeServerOnce sync.Once
+)
+
+// NewNeonApiClientFixture returns a client for the real Neon API when `NEON_API_KEY` is set, and a
+// client for an in-memory fake shared by all tests of the process otherwise.
+func NewNeonApiClientFixture() NeonApiClient {
+	var authToken string
+	if authToken = os.Getenv("NEON_API_KEY"); authToken == "" {
+		sharedFakeServerOnce.Do(func() {
+			sharedFakeServer = neonApiTest.NewServer()
+		})
+		return newFakeNeonApiClient(sharedFakeServer)
+	}
+
+	return NewNeonApiClient(req.C(), authToken)
+}
+
+// NewFakeNeonApiClientFixture returns a client for a dedicated in-memory fake, for tests that
+// need to control the responses of the API.
+func NewFakeNeonApiClientFixture(t *testing.T) (NeonApiClient, *neonApiTest.Server) {
+	server := neonApiTest.NewServer()
+	t.Cleanup(server.Close)
+
+	return newFakeNeonApiClient(server), server
+}
+
+func newFakeNeonApiClient(server *neonApiTest.Server) NeonApiClient {
+	client := NewNeonApiClient(req.C(), server.APIKey)
+	client.SetApiURL(server.URL).SetRetryPolicy(NeonApiRetryPolicy{
+		MaxRetries: 3,
+		MinWait:    time.Millisecond,
+		MaxWait:    10 * time.Millisecond,
+		Budget:     time.Second,
+	})
+
+	return client
+}
+
+func NewDefaultNeonApiClientOptionsFixture() NeonApiClientOptions {
+
+	return NeonApiClientOptions{
+		NumRetries: 0,
+	}
+}
+
+func NewProjectFixture(t *testing.T, cleanup bool) NeonProject {
+	projectName := fmt.Sprintf("Test Project %d", rand.Intn(10000))
+	neonApiClient := NewNeonApiClientFixture()
+
+	createData := NeonProjectCreateData{
+		Project: NeonProjectCreateProjectAttributes{
+			InstanceHandle: "scalable",
+			Name:           projectName,
+			PlatformID:     "aws",
+			RegionID:       "aws-us-west-2",
+			Settings:       map[string]string{},
+		},
+	}
+
+	result, err := neonApiClient.SetDebug(false).ProjectCreate(context.Background(), createData, NewDefaultNeonApiClientOptionsFixture())
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	if cleanup {
+		t.Cleanup(func() {
+			ProjectFixtureDelete(t, result.Project.ID)
+		})
+	}
+
+	return result.Project
+}
+
+// NewBranchFixture creates a branch of the project's default branch. It is removed along with its project.
+func NewBranchFixture(t *testing.T, projectID string) NeonBranch {
+	neonApiClient := NewNeonApiClientFixture()
+
+	createData := NeonBranchCreateData{
+		Branch: NeonBranchCreateBranchAttributes{
+			Name: fmt.Sprintf("test-branch-%d", rand.Intn(10000)),
+		},
+	}
+
+	result, err := neonApiClient.SetDebug(false).BranchCreate(context.Background(), projectID, createData, NewDefaultNeonApiClientOptionsFixture())
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	return result.Branch
+}
@@ -0,0 +1,101 @@
+package neonApi
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/imroc/req/v3"
+)
+
+// LogSubsystem is the tflog subsystem of the client. Its level is set with the TF_LOG_PROVIDER_NEON_API
+// environment variable and otherwise follows TF_LOG_PROVIDER.
+const LogSubsystem = "neon_api"
+
+// RequestIDHeader is the response header carrying the ID the Neon API assigned to a request.
+const RequestIDHeader = "X-Request-Id"
+
+// logMaskedFieldKeys are log fields whose values are always masked.
+var logMaskedFieldKeys = []string{"authorization", "api_key", "password", "dsn"}
+
+// requestLogging holds the logging settings shared by copies of a client.
+type requestLogging struct {
+	redactor redactor
+	// dumps records dumps of retried attempts too, not only of first attempts.
+	dumps atomic.Bool
+}
+
+// newLogContext returns ctx with the neon_api subsystem logger, masking the secrets known to the client.
+func (l *requestLogging) newLogContext(ctx context.Context) context.Context {
+	ctx = tflog.NewSubsystem(ctx, LogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER", LogSubsystem))
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, LogSubsystem, logMaskedFieldKeys...)
+	if len(l.redactor.secrets) > 0 {
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, LogSubsystem, l.redactor.secrets...)
+	}
+	return ctx
+}
+
+// logRequests is a round trip middleware writing a log entry for every attempt of a request, including
+// attempts that failed before a response was received.
+func (l *requestLogging) logRequests(rt req.RoundTripper) req.RoundTripFunc {
+	return func(r *req.Request) (*req.Response, error) {
+		start := time.Now()
+		resp, err := rt.RoundTrip(r)
+
+		ctx := l.newLogContext(r.Context())
+		fields := map[string]interface{}{
+			"method":        r.Method,
+			"path":          r.URL.Path,
+			"duration_ms":   time.Since(start).Milliseconds(),
+			"retry_attempt": r.RetryAttempt,
+		}
+
+		if resp != nil && resp.Response != nil {
+			fields["status_code"] = resp.StatusCode
+			if requestID := resp.Header.Get(RequestIDHeader); requestID != "" {
+				fields["request_id"] = requestID
+			}
+			if operationIDs := responseOperationIDs(resp); len(operationIDs) > 0 {
+				fields["operation_ids"] = operationIDs
+			}
+		}
+
+		if err != nil {
+			fields["error"] = l.redactor.redact(err.Error())
+		}
+
+		tflog.SubsystemDebug(ctx, LogSubsystem, "Neon API request", fields)
+
+		if resp != nil {
+			if dump := resp.Dump(); dump != "" {
+				tflog.SubsystemTrace(ctx, LogSubsystem, "Neon API request dump", map[string]interface{}{
+					"dump": l.redactor.redact(dump),
+				})
+			}
+		}
+
+		return resp, err
+	}
+}
+
+// responseOperationIDs returns the IDs of the operations a mutation response started.
+func responseOperationIDs(resp *req.Response) []string {
+	var body struct {
+		Operations []struct {
+			ID string `json:"id"`
+		} `json:"operations"`
+	}
+
+	data, err := resp.ToBytes()
+	if err != nil || json.Unmarshal(data, &body) != nil {
+		return nil
+	}
+
+	operationIDs := make([]string, 0, len(body.Operations))
+	for _, operation := range body.Operations {
+		operationIDs = append(operationIDs, operation.ID)
+	}
+	return operationIDs
+}
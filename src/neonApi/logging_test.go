@@ -0,0 +1,193 @@
+package neonApi
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+	"virtual-repetitions/terraform-provider-neon/src/neonApiTest"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+// testLogEntries returns the log entries of the neon_api subsystem with the given message.
+func testLogEntries(t *testing.T, output *bytes.Buffer, message string) []map[string]interface{} {
+	entries, err := tflogtest.MultilineJSONDecode(bytes.NewReader(output.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subsystemEntries := []map[string]interface{}{}
+	for _, entry := range entries {
+		if entry["@module"] == "provider."+LogSubsystem && entry["@message"] == message {
+			subsystemEntries = append(subsystemEntries, entry)
+		}
+	}
+	return subsystemEntries
+}
+
+// TestClientLogsRequests verifies every request is logged with structured fields and without secrets
+func TestClientLogsRequests(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	neonApiClient, server := NewFakeNeonApiClientFixture(t)
+
+	result, err := neonApiClient.ProjectCreate(ctx, NeonProjectCreateData{
+		Project: NeonProjectCreateProjectAttributes{
+			InstanceHandle: "scalable",
+			Name:           "test-logging",
+			PlatformID:     "aws",
+			RegionID:       "aws-us-west-2",
+		},
+	}, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, secret := range []string{server.APIKey, result.Response.Roles[0].Password} {
+		if strings.Contains(output.String(), secret) {
+			t.Errorf("Expected %s to be redacted from the logs", secret)
+		}
+	}
+
+	requests := testLogEntries(t, &output, "Neon API request")
+	dumps := testLogEntries(t, &output, "Neon API request dump")
+	if len(requests) != 1 || len(dumps) != 1 {
+		t.Fatalf("Expected a request entry and a dump entry, got %v and %v", requests, dumps)
+	}
+
+	request := requests[0]
+	expected := map[string]interface{}{
+		"@level":        "debug",
+		"method":        http.MethodPost,
+		"path":          "/api/v1/projects",
+		"status_code":   float64(http.StatusCreated),
+		"retry_attempt": float64(0),
+		"request_id":    "req-000001",
+	}
+	for key, value := range expected {
+		if request[key] != value {
+			t.Errorf("Expected %s to be %v, got %v", key, value, request[key])
+		}
+	}
+
+	if operationIDs, ok := request["operation_ids"].([]interface{}); !ok || len(operationIDs) != len(result.Response.Operations) {
+		t.Errorf("Expected the IDs of the started operations, got %v", request["operation_ids"])
+	}
+
+	if _, ok := request["duration_ms"]; !ok {
+		t.Errorf("Expected the duration to be logged")
+	}
+
+	if dump, _ := dumps[0]["dump"].(string); dumps[0]["@level"] != "trace" || !strings.Contains(dump, RedactedValue) {
+		t.Errorf("Expected a redacted trace dump, got %v", dumps[0])
+	}
+}
+
+// TestClientLogsRetries verifies each attempt is logged with its retry attempt
+func TestClientLogsRetries(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	neonApiClient, server := NewFakeNeonApiClientFixture(t)
+	neonApiClient.SetRetryPolicy(NeonApiRetryPolicy{MaxRetries: 1, MinWait: time.Millisecond, MaxWait: time.Second, Budget: time.Second})
+
+	server.Inject(neonApiTest.InjectedResponse{
+		StatusCode: http.StatusServiceUnavailable,
+		Message:    "try again",
+		Times:      1,
+	})
+
+	_, err := neonApiClient.ProjectRead(ctx, "some-project", NewDefaultNeonApiClientOptionsFixture())
+	if !IsNotFound(err) {
+		t.Fatalf("Expected the retry to reach the API, got %v", err)
+	}
+
+	entries := testLogEntries(t, &output, "Neon API request")
+	if len(entries) != 2 {
+		t.Fatalf("Expected an entry per attempt, got %v", entries)
+	}
+
+	if entries[0]["status_code"] != float64(http.StatusServiceUnavailable) || entries[1]["retry_attempt"] != float64(1) {
+		t.Errorf("Expected the failed attempt and the retry to be logged, got %v", entries)
+	}
+
+	if entries[0]["error"] == nil {
+		t.Errorf("Expected the error of the failed attempt to be logged")
+	}
+
+	if retries := testLogEntries(t, &output, "Retrying Neon API request."); len(retries) != 1 || retries[0]["attempt"] != float64(1) {
+		t.Errorf("Expected the retry to be logged through the subsystem, got %v", retries)
+	}
+}
+
+// TestClientLogsOperationWaits verifies operation polls are logged through the subsystem
+func TestClientLogsOperationWaits(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	client, _ := newOperationsTestClient(t, NeonOperationStatusFinished)
+
+	operations := []NeonOperation{{ID: "op-1", ProjectID: "project-1", Status: NeonOperationStatusRunning}}
+	if err := client.OperationsWait(ctx, "project-1", operations, NewDefaultNeonApiClientOptionsFixture()); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := testLogEntries(t, &output, "Waiting for Neon operation.")
+	if len(entries) != 1 || entries[0]["operation_id"] != "op-1" {
+		t.Errorf("Expected the operation wait to be logged through the subsystem, got %v", entries)
+	}
+}
+
+// TestClientLogLevelFromEnv verifies the subsystem level is read from TF_LOG_PROVIDER_NEON_API
+func TestClientLogLevelFromEnv(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER_NEON_API", "WARN")
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	neonApiClient, _ := NewFakeNeonApiClientFixture(t)
+
+	_, err := neonApiClient.RegionList(ctx, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if entries := testLogEntries(t, &output, "Neon API request"); len(entries) != 0 {
+		t.Errorf("Expected debug entries to be filtered, got %v", entries)
+	}
+}
+
+// TestClientLogsDurationWithoutThrottleWait verifies the logged duration of a request leaves out the time it waited for the throttle
+func TestClientLogsDurationWithoutThrottleWait(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	neonApiClient, _ := NewFakeNeonApiClientFixture(t)
+	// A burst of five requests, after which each request waits 200ms.
+	neonApiClient.SetThrottle(NeonApiThrottle{RequestsPerSecond: 5})
+
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		if _, err := neonApiClient.RegionList(ctx, NewDefaultNeonApiClientOptionsFixture()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Fatalf("Expected the requests to be throttled, took %v", elapsed)
+	}
+
+	entries := testLogEntries(t, &output, "Neon API request")
+	if len(entries) != 6 {
+		t.Fatalf("Expected 6 request entries, got %d", len(entries))
+	}
+
+	for _, entry := range entries {
+		if duration, ok := entry["duration_ms"].(float64); !ok || duration >= 150 {
+			t.Errorf("Expected the logged duration to leave out the throttle wait, got %v", entry["duration_ms"])
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package neonApi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Operation statuses reported by the Neon API.
+// See https://api-docs.neon.tech/reference/getprojectoperation
+const (
+	NeonOperationStatusScheduling = "scheduling"
+	NeonOperationStatusRunning    = "running"
+	NeonOperationStatusFinished   = "finished"
+	NeonOperationStatusFailed     = "failed"
+	NeonOperationStatusError      = "error"
+	NeonOperationStatusCancelling = "cancelling"
+	NeonOperationStatusCancelled  = "cancelled"
+	NeonOperationStatusSkipped    = "skipped"
+)
+
+// Polling intervals used while waiting on operations. The interval grows by half after each poll
+// until it reaches operationPollMaxInterval. These are variables so tests can shorten them.
+var (
+	operationPollInitialInterval = 500 * time.Millisecond
+	operationPollMaxInterval     = 5 * time.Second
+	operationPollTimeout         = 15 * time.Minute
+)
+
+type NeonOperation struct {
+	ID            string    `json:"id"`
+	ProjectID     string    `json:"project_id"`
+	BranchID      string    `json:"branch_id"`
+	EndpointID    string    `json:"endpoint_id"`
+	Action        string    `json:"action"`
+	Status        string    `json:"status"`
+	Error         string    `json:"error"`
+	FailuresCount int       `json:"failures_count"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type NeonOperationReadSuccessResponse struct {
+	Operation NeonOperation `json:"operation"`
+}
+
+// NeonOperationError is returned when an operation started by a mutation ends in a failed,
+// errored or cancelled state.
+type NeonOperationError struct {
+	Operation NeonOperation
+}
+
+// NeonOperationTimeoutError is returned when an operation has not completed within the polling timeout.
+type NeonOperationTimeoutError struct {
+	Operation NeonOperation
+	Timeout   time.Duration
+}
+
+func (e NeonOperationError) Error() string {
+	return fmt.Sprintf("Neon operation failed. operation_id: %s action: %s status: %s project_id: %s branch_id: %s endpoint_id: %s error: %s", e.Operation.ID, e.Operation.Action, e.Operation.Status, e.Operation.ProjectID, e.Operation.BranchID, e.Operation.EndpointID, e.Operation.Error)
+}
+
+func (e NeonOperationTimeoutError) Error() string {
+	return fmt.Sprintf("Timed out after %s waiting for Neon operation. operation_id: %s action: %s status: %s project_id: %s", e.Timeout, e.Operation.ID, e.Operation.Action, e.Operation.Status, e.Operation.ProjectID)
+}
+
+// IsFinished reports whether the operation completed successfully.
+func (operation NeonOperation) IsFinished() bool {
+	return operation.Status == NeonOperationStatusFinished || operation.Status == NeonOperationStatusSkipped
+}
+
+// IsFailed reports whether the operation reached a terminal state without completing.
+func (operation NeonOperation) IsFailed() bool {
+	switch operation.Status {
+	case NeonOperationStatusFailed, NeonOperationStatusError, NeonOperationStatusCancelled:
+		return true
+	}
+	return false
+}
+
+func (client *NeonApiClient) OperationRead(ctx context.Context, projectID string, operationID string, options NeonApiClientOptions) (NeonOperation, error) {
+	var response NeonOperationReadSuccessResponse
+
+	_, err := client.newRequest(ctx, options).SetResult(&response).Get(fmt.Sprintf("/api/v2/projects/%s/operations/%s", projectID, operationID))
+
+	return response.Operation, err
+}
+
+// OperationsWait blocks until every given operation has finished or ctx is done. Neon runs the operations of a
+// project one after another, so they are awaited in order and the first failure is returned. Creations wait for
+// their operations this way and return their result alongside the error, as the resource exists by then and
+// callers need its ID to keep track of it.
+func (client *NeonApiClient) OperationsWait(ctx context.Context, projectID string, operations []NeonOperation, options NeonApiClientOptions) error {
+	for _, operation := range operations {
+		if err := client.operationWait(ctx, projectID, operation, options); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (client *NeonApiClient) operationWait(ctx context.Context, projectID string, operation NeonOperation, options NeonApiClientOptions) error {
+	if operation.ProjectID != "" {
+		projectID = operation.ProjectID
+	}
+
+	deadline := time.Now().Add(operationPollTimeout)
+	interval := operationPollInitialInterval
+
+	for {
+		if operation.IsFinished() {
+			return nil
+		}
+
+		if operation.IsFailed() {
+			return NeonOperationError{Operation: operation}
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return NeonOperationTimeoutError{Operation: operation, Timeout: operationPollTimeout}
+		}
+
+		tflog.SubsystemDebug(client.logging.newLogContext(ctx), LogSubsystem, "Waiting for Neon operation.", map[string]interface{}{
+			"operation_id": operation.ID,
+			"action":       operation.Action,
+			"status":       operation.Status,
+			"project_id":   projectID,
+		})
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("Stopped waiting for Neon operation. operation_id: %s action: %s err: %w", operation.ID, operation.Action, ctx.Err())
+		case <-time.After(interval):
+		}
+
+		interval += interval / 2
+		if interval > operationPollMaxInterval {
+			interval = operationPollMaxInterval
+		}
+
+		var err error
+		operation, err = client.OperationRead(ctx, projectID, operation.ID, options)
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package neonApi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/imroc/req/v3"
+)
+
+// newOperationsTestClient returns a client pointed at a server that reports the given statuses,
+// one per poll, for any operation.
+func newOperationsTestClient(t *testing.T, statuses ...string) (NeonApiClient, *int) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[len(statuses)-1]
+		if polls < len(statuses) {
+			status = statuses[polls]
+		}
+		polls++
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(NeonOperationReadSuccessResponse{
+			Operation: NeonOperation{ID: "op-1", ProjectID: "project-1", Action: "create_branch", Status: status, Error: "compute failed to start"},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	initialInterval, maxInterval := operationPollInitialInterval, operationPollMaxInterval
+	operationPollInitialInterval, operationPollMaxInterval = time.Millisecond, time.Millisecond
+	t.Cleanup(func() {
+		operationPollInitialInterval, operationPollMaxInterval = initialInterval, maxInterval
+	})
+
+	client := NewNeonApiClient(req.C(), "test-token")
+	client.SetApiURL(server.URL)
+
+	return client, &polls
+}
+
+// TestOperationsWaitPollsUntilFinished verifies pending operations are polled until they finish
+func TestOperationsWaitPollsUntilFinished(t *testing.T) {
+	client, polls := newOperationsTestClient(t, NeonOperationStatusScheduling, NeonOperationStatusRunning, NeonOperationStatusFinished)
+
+	operations := []NeonOperation{{ID: "op-1", ProjectID: "project-1", Status: NeonOperationStatusRunning}}
+
+	err := client.OperationsWait(context.Background(), "project-1", operations, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Error(err)
+	}
+
+	if *polls != 3 {
+		t.Errorf("Expected operation to be polled 3 times, got %d", *polls)
+	}
+}
+
+// TestOperationsWaitSkipsCompletedOperations verifies operations already finished in the mutation response are not polled
+func TestOperationsWaitSkipsCompletedOperations(t *testing.T) {
+	client, polls := newOperationsTestClient(t, NeonOperationStatusRunning)
+
+	operations := []NeonOperation{
+		{ID: "op-1", ProjectID: "project-1", Status: NeonOperationStatusFinished},
+		{ID: "op-2", ProjectID: "project-1", Status: NeonOperationStatusSkipped},
+	}
+
+	err := client.OperationsWait(context.Background(), "project-1", operations, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Error(err)
+	}
+
+	if *polls != 0 {
+		t.Errorf("Expected no polls, got %d", *polls)
+	}
+}
+
+// TestOperationsWaitReturnsOperationError verifies failed operations surface as NeonOperationError
+func TestOperationsWaitReturnsOperationError(t *testing.T) {
+	client, _ := newOperationsTestClient(t, NeonOperationStatusRunning, NeonOperationStatusFailed)
+
+	operations := []NeonOperation{{ID: "op-1", ProjectID: "project-1", Status: NeonOperationStatusScheduling}}
+
+	err := client.OperationsWait(context.Background(), "project-1", operations, NewDefaultNeonApiClientOptionsFixture())
+
+	var operationErr NeonOperationError
+	if !errors.As(err, &operationErr) {
+		t.Fatalf("Expected NeonOperationError, got %v", err)
+	}
+
+	if operationErr.Operation.Error != "compute failed to start" {
+		t.Errorf("Expected operation error message to be set, got %+v", operationErr.Operation)
+	}
+}
+
+// TestOperationsWaitTimesOut verifies waiting stops once the polling timeout is reached
+func TestOperationsWaitTimesOut(t *testing.T) {
+	client, _ := newOperationsTestClient(t, NeonOperationStatusRunning)
+
+	timeout := operationPollTimeout
+	operationPollTimeout = 20 * time.Millisecond
+	t.Cleanup(func() { operationPollTimeout = timeout })
+
+	operations := []NeonOperation{{ID: "op-1", ProjectID: "project-1", Status: NeonOperationStatusRunning}}
+
+	err := client.OperationsWait(context.Background(), "project-1", operations, NewDefaultNeonApiClientOptionsFixture())
+
+	var timeoutErr NeonOperationTimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("Expected NeonOperationTimeoutError, got %v", err)
+	}
+}
+
+// TestOperationsWaitStopsOnCancellation verifies waiting stops when the context is cancelled
+func TestOperationsWaitStopsOnCancellation(t *testing.T) {
+	client, _ := newOperationsTestClient(t, NeonOperationStatusRunning)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	operations := []NeonOperation{{ID: "op-1", ProjectID: "project-1", Status: NeonOperationStatusRunning}}
+
+	err := client.OperationsWait(ctx, "project-1", operations, NewDefaultNeonApiClientOptionsFixture())
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context deadline error, got %v", err)
+	}
+}
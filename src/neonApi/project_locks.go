@@ -0,0 +1,82 @@
+package neonApi
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// projectLocks serializes the mutations of each project. Neon answers 423 Locked to a mutation while the
+// operations of an earlier one are running, so a mutation holds the lock of its project until its
+// operations have finished. Mutations of different projects run in parallel.
+type projectLocks struct {
+	mu    sync.Mutex
+	locks map[string]*projectLock
+}
+
+// projectLock is the lock of a project. It is dropped once no mutation holds or waits for it.
+type projectLock struct {
+	held chan struct{}
+	refs int
+}
+
+// lockProject locks the project for a mutation. The cached reads of the project are invalidated when the
+// mutation starts and once it has finished, as reads completing in between may predate its changes.
+func (client *NeonApiClient) lockProject(ctx context.Context, projectID string) (func(), error) {
+	unlock, err := client.projects.lock(client.logging.newLogContext(ctx), projectID)
+	if err != nil {
+		return nil, err
+	}
+	client.reads.invalidate(projectID)
+
+	return func() {
+		client.reads.invalidate(projectID)
+		unlock()
+	}, nil
+}
+
+// lock waits until no other mutation of the project is in progress, or until ctx is done. The returned
+// function releases the lock.
+func (l *projectLocks) lock(ctx context.Context, projectID string) (func(), error) {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = map[string]*projectLock{}
+	}
+	lock, ok := l.locks[projectID]
+	if !ok {
+		lock = &projectLock{held: make(chan struct{}, 1)}
+		l.locks[projectID] = lock
+	}
+	lock.refs++
+	l.mu.Unlock()
+
+	select {
+	case lock.held <- struct{}{}:
+	default:
+		tflog.SubsystemDebug(ctx, LogSubsystem, "Waiting for another mutation of the Neon project to finish.", map[string]interface{}{"project_id": projectID})
+
+		select {
+		case lock.held <- struct{}{}:
+		case <-ctx.Done():
+			l.release(projectID, lock)
+			return nil, ctx.Err()
+		}
+	}
+
+	return func() {
+		<-lock.held
+		l.release(projectID, lock)
+	}, nil
+}
+
+// release drops a reference to the lock of the project, removing the lock once it is unused.
+func (l *projectLocks) release(projectID string, lock *projectLock) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lock.refs--
+	if lock.refs == 0 {
+		delete(l.locks, projectID)
+	}
+}
@@ -0,0 +1,136 @@
+package neonApi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestProjectMutationsAreSerialized verifies concurrent mutations of a project wait for each other instead of being locked out
+func TestProjectMutationsAreSerialized(t *testing.T) {
+	neonApiClient, server := NewFakeNeonApiClientFixture(t)
+	// Without retries, a mutation sent while operations are running fails with 423 Locked.
+	neonApiClient.SetRetryPolicy(NeonApiRetryPolicy{})
+	server.OperationDuration = 20 * time.Millisecond
+
+	initialInterval, maxInterval := operationPollInitialInterval, operationPollMaxInterval
+	operationPollInitialInterval, operationPollMaxInterval = time.Millisecond, time.Millisecond
+	t.Cleanup(func() {
+		operationPollInitialInterval, operationPollMaxInterval = initialInterval, maxInterval
+	})
+
+	result, err := neonApiClient.ProjectCreate(context.Background(), NeonProjectCreateData{
+		Project: NeonProjectCreateProjectAttributes{
+			InstanceHandle: "scalable",
+			Name:           "test-locks",
+			PlatformID:     "aws",
+			RegionID:       "aws-us-west-2",
+		},
+	}, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Fatal(err)
+	}
+	project := result.Project
+
+	var wg sync.WaitGroup
+	errs := make([]error, 4)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = neonApiClient.BranchCreate(context.Background(), project.ID, NeonBranchCreateData{
+				Branch: NeonBranchCreateBranchAttributes{Name: fmt.Sprintf("branch-%d", i)},
+			}, NewDefaultNeonApiClientOptionsFixture())
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("Expected branch %d to be created, got %v", i, err)
+		}
+	}
+}
+
+// TestProjectLocksAreIndependent verifies a project lock only blocks mutations of the same project
+func TestProjectLocksAreIndependent(t *testing.T) {
+	locks := &projectLocks{}
+
+	unlock, err := locks.lock(context.Background(), "project-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unlockOther, err := locks.lock(context.Background(), "project-2")
+	if err != nil {
+		t.Fatalf("Expected another project not to wait, got %v", err)
+	}
+	unlockOther()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := locks.lock(ctx, "project-1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected a locked project to wait until the context is done, got %v", err)
+	}
+
+	unlock()
+
+	unlock, err = locks.lock(context.Background(), "project-1")
+	if err != nil {
+		t.Fatalf("Expected a released project to be locked again, got %v", err)
+	}
+	unlock()
+}
+
+// TestProjectLocksAreRemoved verifies the lock of a project is dropped once no mutation holds or waits for it
+func TestProjectLocksAreRemoved(t *testing.T) {
+	locks := &projectLocks{}
+
+	unlock, err := locks.lock(context.Background(), "project-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := locks.lock(ctx, "project-1"); err == nil {
+		t.Fatal("Expected the locked project to make the mutation wait")
+	}
+
+	acquired := make(chan func())
+	go func() {
+		unlockWaiting, err := locks.lock(context.Background(), "project-1")
+		if err != nil {
+			t.Error(err)
+		}
+		acquired <- unlockWaiting
+	}()
+
+	// Wait for the mutation to queue up behind the held lock.
+	for {
+		locks.mu.Lock()
+		refs := locks.locks["project-1"].refs
+		locks.mu.Unlock()
+		if refs == 2 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	unlock()
+	unlockWaiting := <-acquired
+
+	if count := len(locks.locks); count != 1 {
+		t.Errorf("Expected the lock to be kept while it is held, got %d locks", count)
+	}
+
+	unlockWaiting()
+
+	if count := len(locks.locks); count != 0 {
+		t.Errorf("Expected the lock to be dropped once released, got %d locks", count)
+	}
+}
@@ -1,9 +1,8 @@
 package neonApi
 
 import (
-	"errors"
+	"context"
 	"fmt"
-	"regexp"
 	"time"
 )
 
@@ -35,6 +34,8 @@ type NeonProjectMutationSuccessResponse struct {
 	InstanceTypeID string                                       `json:"instance_type_id"`
 	MaxProjectSize int                                          `json:"max_project_size"`
 	Name           string                                       `json:"name"`
+	Operations     []NeonOperation                              `json:"operations"`
+	OrgID          string                                       `json:"org_id"`
 	ParentID       string                                       `json:"parent_id"`
 	PendingState   string                                       `json:"pending_state"`
 	PlatformID     string                                       `json:"platform_id"`
@@ -54,11 +55,34 @@ type NeonProject struct {
 	InstanceHandle string            `json:"instance_handle"`
 	InstanceTypeID string            `json:"instance_type_id"`
 	ParentID       string            `json:"parent_id"`
+	OrgID          string            `json:"org_id"`
 	PlatformID     string            `json:"platform_id"`
+	PoolerEnabled  bool              `json:"pooler_enabled"`
 	RegionID       string            `json:"region_id"`
 	Settings       map[string]string `json:"settings"`
 }
 
+// NeonProjectListPageLimit is the default number of projects requested per page when listing projects.
+const NeonProjectListPageLimit = 100
+
+type NeonProjectListSuccessResponse struct {
+	Projects   []NeonProject  `json:"projects"`
+	Pagination NeonPagination `json:"pagination"`
+}
+
+// NeonPagination holds the cursor to pass to get the page after the one returned.
+type NeonPagination struct {
+	Cursor string `json:"cursor"`
+}
+
+// NeonProjectListParams filters the projects to list. Empty values list every project.
+type NeonProjectListParams struct {
+	OrgID string
+
+	// PageLimit overrides NeonProjectListPageLimit when greater than zero.
+	PageLimit int
+}
+
 type NeonProjectCreateData struct {
 	Project NeonProjectCreateProjectAttributes `json:"project"`
 }
@@ -66,6 +90,7 @@ type NeonProjectCreateData struct {
 type NeonProjectCreateProjectAttributes struct {
 	InstanceHandle string            `json:"instance_handle"`
 	Name           string            `json:"name"`
+	OrgID          string            `json:"org_id,omitempty"`
 	PlatformID     string            `json:"platform_id"`
 	RegionID       string            `json:"region_id"`
 	Settings       map[string]string `json:"settings"`
@@ -82,7 +107,7 @@ type NeonProjectUpdateProjectAttributes struct {
 	Settings       map[string]string `json:"settings"`
 }
 
-func (client *NeonApiClient) ProjectCreate(data NeonProjectCreateData, options NeonApiClientOptions) (NeonProjectMutationResult, error) {
+func (client *NeonApiClient) ProjectCreate(ctx context.Context, data NeonProjectCreateData, options NeonApiClientOptions) (NeonProjectMutationResult, error) {
 	var response NeonProjectMutationSuccessResponse
 
 	normalizedRegionID, err := normalizeRegionID(data.Project.RegionID)
@@ -92,7 +117,11 @@ func (client *NeonApiClient) ProjectCreate(data NeonProjectCreateData, options N
 
 	data.Project.RegionID = normalizedRegionID
 
-	_, err = client.NewRequest().SetRetryCount(options.NumRetries).SetResult(&response).SetBody(data).Post(fmt.Sprintf("/api/v1/projects"))
+	_, err = client.newRequest(ctx, options).SetResult(&response).SetBody(data).Post(fmt.Sprintf("/api/v1/projects"))
+
+	if err != nil {
+		return NeonProjectMutationResult{}, err
+	}
 
 	result := NeonProjectMutationResult{
 		Project: NeonProject{
@@ -101,22 +130,32 @@ func (client *NeonApiClient) ProjectCreate(data NeonProjectCreateData, options N
 			InstanceHandle: response.InstanceHandle,
 			InstanceTypeID: response.InstanceTypeID,
 			ParentID:       response.ParentID,
+			OrgID:          response.OrgID,
 			PlatformID:     response.PlatformID,
+			PoolerEnabled:  response.PoolerEnabled,
 			RegionID:       response.RegionID,
 			Settings:       response.Settings,
 		},
 		Response: response,
 	}
+
+	err = client.OperationsWait(ctx, response.ID, response.Operations, options)
+
 	return result, err
 }
 
-func (client *NeonApiClient) ProjectUpdate(projectID string, data NeonProjectUpdateData, options NeonApiClientOptions) (NeonProjectMutationResult, error) {
+func (client *NeonApiClient) ProjectUpdate(ctx context.Context, projectID string, data NeonProjectUpdateData, options NeonApiClientOptions) (NeonProjectMutationResult, error) {
+	unlock, err := client.lockProject(ctx, projectID)
+	if err != nil {
+		return NeonProjectMutationResult{}, err
+	}
+	defer unlock()
+
 	var response NeonProjectMutationSuccessResponse
 
-	_, err := client.NewRequest().SetRetryCount(options.NumRetries).SetResult(&response).SetBody(data).Patch(fmt.Sprintf("/api/v1/projects/%s", projectID))
+	_, err = client.newRequest(ctx, options).SetResult(&response).SetBody(data).Patch(fmt.Sprintf("/api/v1/projects/%s", projectID))
 
 	if err != nil {
-		fmt.Printf("Error updating project. body data: %+v err: %s projectID: %s", data, err, projectID)
 		return NeonProjectMutationResult{}, err
 	}
 
@@ -127,48 +166,79 @@ func (client *NeonApiClient) ProjectUpdate(projectID string, data NeonProjectUpd
 			InstanceHandle: response.InstanceHandle,
 			InstanceTypeID: response.InstanceTypeID,
 			ParentID:       response.ParentID,
+			OrgID:          response.OrgID,
 			PlatformID:     response.PlatformID,
+			PoolerEnabled:  response.PoolerEnabled,
 			RegionID:       response.RegionID,
 			Settings:       response.Settings,
 		},
 		Response: response,
 	}
+
+	err = client.OperationsWait(ctx, projectID, response.Operations, options)
+
 	return result, err
 }
 
-func (client *NeonApiClient) ProjectDelete(projectID string, options NeonApiClientOptions) error {
+func (client *NeonApiClient) ProjectDelete(ctx context.Context, projectID string, options NeonApiClientOptions) error {
+	unlock, err := client.lockProject(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
-	var project NeonProject
-	_, err := client.NewRequest().SetRetryCount(options.NumRetries).SetResult(&project).Post(fmt.Sprintf("/api/v1/projects/%s/delete", projectID))
+	var response NeonProjectMutationSuccessResponse
+	_, err = client.newRequest(ctx, options).SetResult(&response).Post(fmt.Sprintf("/api/v1/projects/%s/delete", projectID))
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	return client.OperationsWait(ctx, projectID, response.Operations, options)
 }
 
-func (client *NeonApiClient) ProjectRead(projectID string, options NeonApiClientOptions) (NeonProject, error) {
+func (client *NeonApiClient) ProjectRead(ctx context.Context, projectID string, options NeonApiClientOptions) (NeonProject, error) {
 
 	var project NeonProject
-	_, err := client.NewRequest().SetRetryCount(options.NumRetries).SetResult(&project).Get(fmt.Sprintf("/api/v1/projects/%s", projectID))
+	_, err := client.newRequest(ctx, options).SetResult(&project).Get(fmt.Sprintf("/api/v1/projects/%s", projectID))
 
 	return project, err
 }
 
-// Neon API expects region IDs for input data to be of form `us-west-2` but returns `aws-us-west-2` in response data
-// This supports `aws-us-west-2` for consistency
-func normalizeRegionID(regionID string) (string, error) {
-	re := regexp.MustCompile("[A-Za-z]+-(?P<regionID>.*)")
-	match := re.FindStringSubmatch(regionID)
+// ProjectList returns every project visible to the API key, following pagination until the last page.
+func (client *NeonApiClient) ProjectList(ctx context.Context, params NeonProjectListParams, options NeonApiClientOptions) ([]NeonProject, error) {
+	var projects []NeonProject
+	cursor := ""
 
-	result := make(map[string]string)
-	for i, name := range re.SubexpNames() {
-		if i != 0 && name != "" {
-			result[name] = match[i]
-		}
+	limit := NeonProjectListPageLimit
+	if params.PageLimit > 0 {
+		limit = params.PageLimit
 	}
-	normalizedRegionID, valid := result["regionID"]
 
-	if !valid {
-		return "", errors.New(fmt.Sprintf("Could not parse region ID. Expected to be of form `aws-us-west-2`. given: %s", regionID))
-	}
+	for {
+		var response NeonProjectListSuccessResponse
 
-	return normalizedRegionID, nil
+		request := client.newRequest(ctx, options).SetResult(&response).SetQueryParam("limit", fmt.Sprint(limit))
+		if cursor != "" {
+			request.SetQueryParam("cursor", cursor)
+		}
+		if params.OrgID != "" {
+			request.SetQueryParam("org_id", params.OrgID)
+		}
+
+		_, err := request.Get("/api/v2/projects")
+
+		if err != nil {
+			return nil, err
+		}
+
+		projects = append(projects, response.Projects...)
+
+		// A short page, or a cursor that does not move, is the last page.
+		if len(response.Projects) < limit || response.Pagination.Cursor == "" || response.Pagination.Cursor == cursor {
+			return projects, nil
+		}
+
+		cursor = response.Pagination.Cursor
+	}
 }
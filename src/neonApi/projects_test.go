@@ -1,8 +1,11 @@
 package neonApi
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math/rand"
+	"net/http"
 	"testing"
 )
 
@@ -22,7 +25,7 @@ func TestProjectCreate(t *testing.T) {
 		},
 	}
 
-	result, err := neonApiClient.SetDebug(false).ProjectCreate(createData, NewDefaultNeonApiClientOptionsFixture())
+	result, err := neonApiClient.SetDebug(false).ProjectCreate(context.Background(), createData, NewDefaultNeonApiClientOptionsFixture())
 
 	if err != nil {
 		t.Error(err)
@@ -45,7 +48,7 @@ func TestProjectRead(t *testing.T) {
 
 	neonApiClient := NewNeonApiClientFixture()
 
-	project, err := neonApiClient.SetDebug(false).ProjectRead(projectFixture.ID, NewDefaultNeonApiClientOptionsFixture())
+	project, err := neonApiClient.SetDebug(false).ProjectRead(context.Background(), projectFixture.ID, NewDefaultNeonApiClientOptionsFixture())
 	if err != nil {
 		t.Error(err)
 	}
@@ -68,7 +71,7 @@ func TestProjectUpdate(t *testing.T) {
 			Settings:       map[string]string{},
 		},
 	}
-	result, err := neonApiClient.SetDebug(false).ProjectUpdate(projectFixture.ID, updateData, NewDefaultNeonApiClientOptionsFixture())
+	result, err := neonApiClient.SetDebug(false).ProjectUpdate(context.Background(), projectFixture.ID, updateData, NewDefaultNeonApiClientOptionsFixture())
 	if err != nil {
 		t.Error(err)
 	}
@@ -83,7 +86,7 @@ func TestProjectReadOnInvalidProject(t *testing.T) {
 	projectID := "some-nonexistent-project"
 	neonApiClient := NewNeonApiClientFixture()
 
-	_, err := neonApiClient.ProjectRead(projectID, NewDefaultNeonApiClientOptionsFixture())
+	_, err := neonApiClient.ProjectRead(context.Background(), projectID, NewDefaultNeonApiClientOptionsFixture())
 	if err == nil {
 		t.Error("Expected to receive error, got nil error instead.")
 	}
@@ -94,13 +97,87 @@ func TestProjectDelete(t *testing.T) {
 	projectFixture := NewProjectFixture(t, false)
 	neonApiClient := NewNeonApiClientFixture()
 
-	err := neonApiClient.ProjectDelete(projectFixture.ID, NewDefaultNeonApiClientOptionsFixture())
+	err := neonApiClient.ProjectDelete(context.Background(), projectFixture.ID, NewDefaultNeonApiClientOptionsFixture())
 	if err != nil {
 		t.Errorf("Could not delete project. err: %s", err)
 	}
 
-	project, err := neonApiClient.SetDebug(false).ProjectRead(projectFixture.ID, NewDefaultNeonApiClientOptionsFixture())
+	project, err := neonApiClient.SetDebug(false).ProjectRead(context.Background(), projectFixture.ID, NewDefaultNeonApiClientOptionsFixture())
 	if project.ID != "" {
 		t.Errorf("Project was not deleted.")
 	}
 }
+
+// TestProjectCreateReturnsOperationError verifies a failed project operation is returned along with the created project
+func TestProjectCreateReturnsOperationError(t *testing.T) {
+	neonApiClient, server := NewFakeNeonApiClientFixture(t)
+	server.FailNextOperation("timeline creation failed")
+
+	createData := NeonProjectCreateData{
+		Project: NeonProjectCreateProjectAttributes{
+			InstanceHandle: "scalable",
+			Name:           "failing-project",
+			PlatformID:     "aws",
+			RegionID:       "aws-us-west-2",
+			Settings:       map[string]string{},
+		},
+	}
+
+	result, err := neonApiClient.ProjectCreate(context.Background(), createData, NewDefaultNeonApiClientOptionsFixture())
+
+	var operationErr NeonOperationError
+	if !errors.As(err, &operationErr) {
+		t.Fatalf("Expected NeonOperationError, got %v", err)
+	}
+
+	if result.Project.ID == "" {
+		t.Errorf("Expected created project to be returned with the operation error")
+	}
+}
+
+// TestProjectList verifies every page of projects is listed and filtered by organization
+func TestProjectList(t *testing.T) {
+	neonApiClient, server := NewFakeNeonApiClientFixture(t)
+
+	for i := 0; i < 5; i++ {
+		orgID := ""
+		if i%2 == 0 {
+			orgID = "org-test"
+		}
+
+		_, err := neonApiClient.ProjectCreate(context.Background(), NeonProjectCreateData{
+			Project: NeonProjectCreateProjectAttributes{
+				InstanceHandle: "scalable",
+				Name:           fmt.Sprintf("list-project-%d", i),
+				OrgID:          orgID,
+				PlatformID:     "aws",
+				RegionID:       "aws-us-west-2",
+			},
+		}, NewDefaultNeonApiClientOptionsFixture())
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	projects, err := neonApiClient.ProjectList(context.Background(), NeonProjectListParams{PageLimit: 2}, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(projects) != 5 || projects[0].Name != "list-project-0" || projects[4].Name != "list-project-4" {
+		t.Errorf("Expected the 5 projects in order, got %+v", projects)
+	}
+
+	if count := server.RequestCount(http.MethodGet, "/api/v2/projects"); count != 3 {
+		t.Errorf("Expected 3 pages to be requested, got %d", count)
+	}
+
+	projects, err = neonApiClient.ProjectList(context.Background(), NeonProjectListParams{OrgID: "org-test"}, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(projects) != 3 || projects[0].OrgID != "org-test" {
+		t.Errorf("Expected the 3 projects of the organization, got %+v", projects)
+	}
+}
@@ -0,0 +1,122 @@
+package neonApi
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// readCacheMaxAge is how long a listed project list is served from the cache. It covers the refresh of a
+// project's resources, while keeping states such as the current state of a branch from going stale during
+// long applies.
+var readCacheMaxAge = 10 * time.Second
+
+// projectReadCache holds the branches and endpoints of projects listed by a client, so that reading
+// every branch or endpoint of a project during a refresh costs a single request. It is shared by the
+// copies of the client. Entries expire after readCacheMaxAge, and mutations of a project invalidate its
+// entries.
+type projectReadCache struct {
+	branches  readCache[NeonBranch]
+	endpoints readCache[NeonEndpoint]
+}
+
+// invalidate drops the cached lists of the project. Lists being fetched when the project is invalidated
+// are still handed to the readers waiting for them, but are not cached.
+func (c *projectReadCache) invalidate(projectID string) {
+	c.branches.invalidate(projectID)
+	c.endpoints.invalidate(projectID)
+}
+
+// readCache caches a list per project. Concurrent reads of a list that is not cached yet share a single
+// request.
+type readCache[T any] struct {
+	mu      sync.Mutex
+	entries map[string]*readCacheEntry[T]
+}
+
+type readCacheEntry[T any] struct {
+	done    chan struct{}
+	items   []T
+	err     error
+	expires time.Time
+}
+
+// expired reports whether the entry has been fetched and is older than readCacheMaxAge.
+func (e *readCacheEntry[T]) expired(now time.Time) bool {
+	select {
+	case <-e.done:
+		return now.After(e.expires)
+	default:
+		return false
+	}
+}
+
+// get returns the cached list of the project, calling fetch when it is not cached or being fetched yet,
+// or when the cached list has expired. Failed fetches are not cached.
+func (c *readCache[T]) get(ctx context.Context, projectID string, fetch func() ([]T, error)) ([]T, error) {
+	for {
+		c.mu.Lock()
+		if c.entries == nil {
+			c.entries = map[string]*readCacheEntry[T]{}
+		}
+		entry, ok := c.entries[projectID]
+		if ok && entry.expired(time.Now()) {
+			ok = false
+		}
+		if !ok {
+			entry = &readCacheEntry[T]{done: make(chan struct{})}
+			c.entries[projectID] = entry
+		}
+		c.mu.Unlock()
+
+		if !ok {
+			entry.items, entry.err = fetch()
+			entry.expires = time.Now().Add(readCacheMaxAge)
+			if entry.err != nil {
+				c.drop(projectID, entry)
+			}
+			close(entry.done)
+			return copyItems(entry.items), entry.err
+		}
+
+		select {
+		case <-entry.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		// The reader fetching the list gave up, which is no reason for this one to.
+		if errors.Is(entry.err, context.Canceled) || errors.Is(entry.err, context.DeadlineExceeded) {
+			if ctx.Err() == nil {
+				continue
+			}
+		}
+		return copyItems(entry.items), entry.err
+	}
+}
+
+func (c *readCache[T]) invalidate(projectID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, projectID)
+}
+
+// drop removes entry unless the project was invalidated and fetched again in the meantime.
+func (c *readCache[T]) drop(projectID string, entry *readCacheEntry[T]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries[projectID] == entry {
+		delete(c.entries, projectID)
+	}
+}
+
+// copyItems keeps callers from modifying cached lists.
+func copyItems[T any](items []T) []T {
+	if items == nil {
+		return nil
+	}
+	return append(make([]T, 0, len(items)), items...)
+}
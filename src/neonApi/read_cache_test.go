@@ -0,0 +1,286 @@
+package neonApi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+	"virtual-repetitions/terraform-provider-neon/src/neonApiTest"
+)
+
+func newReadCacheTestProject(t *testing.T, neonApiClient NeonApiClient) NeonProject {
+	result, err := neonApiClient.ProjectCreate(context.Background(), NeonProjectCreateData{
+		Project: NeonProjectCreateProjectAttributes{
+			InstanceHandle: "scalable",
+			Name:           "test-read-cache",
+			PlatformID:     "aws",
+			RegionID:       "aws-us-west-2",
+		},
+	}, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result.Project
+}
+
+// TestBranchAndEndpointReadsShareProjectLists verifies reading every branch and endpoint of a project lists each once
+func TestBranchAndEndpointReadsShareProjectLists(t *testing.T) {
+	neonApiClient, server := NewFakeNeonApiClientFixture(t)
+	project := newReadCacheTestProject(t, neonApiClient)
+
+	branchIDs := []string{}
+	for i := 0; i < 3; i++ {
+		result, err := neonApiClient.BranchCreate(context.Background(), project.ID, NeonBranchCreateData{
+			Branch:    NeonBranchCreateBranchAttributes{Name: fmt.Sprintf("branch-%d", i)},
+			Endpoints: []NeonBranchCreateEndpointAttributes{{Type: "read_write"}},
+		}, NewDefaultNeonApiClientOptionsFixture())
+		if err != nil {
+			t.Fatal(err)
+		}
+		branchIDs = append(branchIDs, result.Branch.ID)
+	}
+
+	for _, branchID := range branchIDs {
+		branch, err := neonApiClient.BranchRead(context.Background(), project.ID, branchID, NewDefaultNeonApiClientOptionsFixture())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if branch.ID != branchID {
+			t.Errorf("Expected branch %s, got %s", branchID, branch.ID)
+		}
+	}
+
+	endpoints := server.Endpoints(project.ID)
+	for _, endpoint := range endpoints {
+		read, err := neonApiClient.EndpointRead(context.Background(), project.ID, endpoint.ID, NewDefaultNeonApiClientOptionsFixture())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if read.ID != endpoint.ID || read.BranchID != endpoint.BranchID {
+			t.Errorf("Expected endpoint %s of branch %s, got %+v", endpoint.ID, endpoint.BranchID, read)
+		}
+	}
+
+	branchesPath := fmt.Sprintf("/api/v2/projects/%s/branches", project.ID)
+	endpointsPath := fmt.Sprintf("/api/v2/projects/%s/endpoints", project.ID)
+	if count := server.RequestCount(http.MethodGet, branchesPath); count != 1 {
+		t.Errorf("Expected branches to be listed once, got %d requests", count)
+	}
+	if count := server.RequestCount(http.MethodGet, endpointsPath); count != 1 {
+		t.Errorf("Expected endpoints to be listed once, got %d requests", count)
+	}
+	for _, branchID := range branchIDs {
+		if count := server.RequestCount(http.MethodGet, branchesPath+"/"+branchID); count != 0 {
+			t.Errorf("Expected branch %s to be read from the list, got %d requests", branchID, count)
+		}
+	}
+	for _, endpoint := range endpoints {
+		if count := server.RequestCount(http.MethodGet, endpointsPath+"/"+endpoint.ID); count != 0 {
+			t.Errorf("Expected endpoint %s to be read from the list, got %d requests", endpoint.ID, count)
+		}
+	}
+}
+
+// TestBranchReadMissingFromList verifies branches missing from the cached list are read from the API
+func TestBranchReadMissingFromList(t *testing.T) {
+	neonApiClient, server := NewFakeNeonApiClientFixture(t)
+	project := newReadCacheTestProject(t, neonApiClient)
+
+	_, err := neonApiClient.BranchRead(context.Background(), project.ID, "br-missing", NewDefaultNeonApiClientOptionsFixture())
+	if !IsNotFound(err) {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+
+	if count := server.RequestCount(http.MethodGet, fmt.Sprintf("/api/v2/projects/%s/branches/br-missing", project.ID)); count != 1 {
+		t.Errorf("Expected the missing branch to be read once, got %d requests", count)
+	}
+}
+
+// TestBranchReadReturnsListErrors verifies failures listing the branches are returned instead of reading the branch
+func TestBranchReadReturnsListErrors(t *testing.T) {
+	neonApiClient, server := NewFakeNeonApiClientFixture(t)
+	project := newReadCacheTestProject(t, neonApiClient)
+	branch := server.Branches(project.ID)[0]
+
+	branchesPath := fmt.Sprintf("/api/v2/projects/%s/branches", project.ID)
+	server.Inject(neonApiTest.InjectedResponse{
+		Method:     http.MethodGet,
+		Path:       branchesPath,
+		StatusCode: http.StatusForbidden,
+		Message:    "forbidden",
+		Times:      1,
+	})
+
+	_, err := neonApiClient.BranchRead(context.Background(), project.ID, branch.ID, NewDefaultNeonApiClientOptionsFixture())
+	if !IsForbidden(err) {
+		t.Errorf("Expected the listing error, got %v", err)
+	}
+
+	if count := server.RequestCount(http.MethodGet, branchesPath+"/"+branch.ID); count != 0 {
+		t.Errorf("Expected the branch not to be read after the listing failed, got %d requests", count)
+	}
+}
+
+// TestReadCacheExpires verifies lists are fetched again once they are older than readCacheMaxAge
+func TestReadCacheExpires(t *testing.T) {
+	maxAge := readCacheMaxAge
+	readCacheMaxAge = 10 * time.Millisecond
+	t.Cleanup(func() { readCacheMaxAge = maxAge })
+
+	cache := &readCache[string]{}
+	fetches := 0
+	fetch := func() ([]string, error) {
+		fetches++
+		return []string{"a"}, nil
+	}
+
+	cache.get(context.Background(), "project", fetch)
+	cache.get(context.Background(), "project", fetch)
+	if fetches != 1 {
+		t.Fatalf("Expected a fresh list to be served from the cache, got %d fetches", fetches)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	cache.get(context.Background(), "project", fetch)
+	if fetches != 2 {
+		t.Errorf("Expected an expired list to be fetched again, got %d fetches", fetches)
+	}
+}
+
+// TestReadCacheInvalidatedOnMutation verifies lists are fetched again once the project has been mutated
+func TestReadCacheInvalidatedOnMutation(t *testing.T) {
+	neonApiClient, server := NewFakeNeonApiClientFixture(t)
+	project := newReadCacheTestProject(t, neonApiClient)
+
+	branches, err := neonApiClient.BranchList(context.Background(), project.ID, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(branches) != 1 {
+		t.Fatalf("Expected the default branch only, got %+v", branches)
+	}
+
+	result, err := neonApiClient.BranchCreate(context.Background(), project.ID, NeonBranchCreateData{
+		Branch: NeonBranchCreateBranchAttributes{Name: "new-branch"},
+	}, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	branch, err := neonApiClient.BranchRead(context.Background(), project.ID, result.Branch.ID, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if branch.Name != "new-branch" {
+		t.Errorf("Expected the new branch, got %+v", branch)
+	}
+
+	if count := server.RequestCount(http.MethodGet, fmt.Sprintf("/api/v2/projects/%s/branches", project.ID)); count != 2 {
+		t.Errorf("Expected branches to be listed again after the mutation, got %d requests", count)
+	}
+}
+
+// TestReadCacheCoalescesConcurrentReads verifies concurrent reads of a list share a single fetch
+func TestReadCacheCoalescesConcurrentReads(t *testing.T) {
+	cache := &readCache[string]{}
+	release := make(chan struct{})
+	var fetches int32
+
+	fetch := func() ([]string, error) {
+		atomic.AddInt32(&fetches, 1)
+		<-release
+		return []string{"a", "b"}, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([][]string, 5)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = cache.get(context.Background(), "project", fetch)
+		}(i)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if fetches != 1 {
+		t.Errorf("Expected a single fetch, got %d", fetches)
+	}
+	for i, result := range results {
+		if len(result) != 2 {
+			t.Errorf("Expected read %d to get the list, got %v", i, result)
+		}
+	}
+
+	// Modifying a result leaves the cached list alone.
+	results[0][0] = "modified"
+	cached, _ := cache.get(context.Background(), "project", fetch)
+	if cached[0] != "a" || fetches != 1 {
+		t.Errorf("Expected the cached list to be unchanged, got %v after %d fetches", cached, fetches)
+	}
+}
+
+// TestReadCacheDoesNotCacheFailures verifies failed and invalidated fetches are fetched again
+func TestReadCacheDoesNotCacheFailures(t *testing.T) {
+	cache := &readCache[string]{}
+	fetches := 0
+	fetch := func() ([]string, error) {
+		fetches++
+		if fetches == 1 {
+			return nil, errors.New("unavailable")
+		}
+		return []string{"a"}, nil
+	}
+
+	if _, err := cache.get(context.Background(), "project", fetch); err == nil {
+		t.Fatal("Expected the first fetch to fail")
+	}
+	if items, err := cache.get(context.Background(), "project", fetch); err != nil || len(items) != 1 {
+		t.Fatalf("Expected the list to be fetched again, got %v, %v", items, err)
+	}
+
+	cache.invalidate("project")
+	cache.get(context.Background(), "project", fetch)
+	if fetches != 3 {
+		t.Errorf("Expected the list to be fetched again after invalidation, got %d fetches", fetches)
+	}
+}
+
+// TestReadCacheWaitersOutliveCancelledFetch verifies a read waiting on a fetch whose reader gave up fetches the list itself
+func TestReadCacheWaitersOutliveCancelledFetch(t *testing.T) {
+	cache := &readCache[string]{}
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+
+	go cache.get(ctx, "project", func() ([]string, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	<-started
+
+	done := make(chan struct{})
+	var items []string
+	var err error
+	go func() {
+		defer close(done)
+		items, err = cache.get(context.Background(), "project", func() ([]string, error) {
+			return []string{"a"}, nil
+		})
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	<-done
+
+	if err != nil || len(items) != 1 {
+		t.Errorf("Expected the waiting read to get the list, got %v, %v", items, err)
+	}
+}
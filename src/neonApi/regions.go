@@ -0,0 +1,237 @@
+package neonApi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultNeonPlatformID is the platform assumed for region IDs given without a platform prefix.
+const DefaultNeonPlatformID = "aws"
+
+// neonPlatformIDs are the platforms region IDs may be prefixed with, as in `aws-us-west-2`.
+var neonPlatformIDs = []string{"aws", "azure", "gcp"}
+
+type NeonRegion struct {
+	RegionID string `json:"region_id"`
+	Name     string `json:"name"`
+	Default  bool   `json:"default"`
+}
+
+// PlatformID returns the cloud platform of the region, such as `aws`.
+func (region NeonRegion) PlatformID() string {
+	platformID, _ := SplitRegionID(region.RegionID)
+	if platformID == "" {
+		return DefaultNeonPlatformID
+	}
+	return platformID
+}
+
+type NeonRegionListSuccessResponse struct {
+	Regions []NeonRegion `json:"regions"`
+}
+
+// NeonFallbackRegions is the region catalog used when the regions endpoint of the API is not available.
+var NeonFallbackRegions = []NeonRegion{
+	{RegionID: "aws-us-east-2", Name: "US East (Ohio)", Default: true},
+	{RegionID: "aws-us-west-2", Name: "US West (Oregon)"},
+	{RegionID: "aws-eu-central-1", Name: "Europe (Frankfurt)"},
+	{RegionID: "aws-ap-southeast-1", Name: "Asia Pacific (Singapore)"},
+}
+
+// NeonRegionCatalog lists the regions projects and endpoints can be created in.
+type NeonRegionCatalog struct {
+	Regions []NeonRegion
+}
+
+// regionCatalogCache holds the catalog of a client once it has been listed. It is shared by the copies
+// of the client.
+type regionCatalogCache struct {
+	mu      sync.Mutex
+	catalog *NeonRegionCatalog
+}
+
+func (client *NeonApiClient) RegionList(ctx context.Context, options NeonApiClientOptions) ([]NeonRegion, error) {
+	var response NeonRegionListSuccessResponse
+
+	_, err := client.newRequest(ctx, options).SetResult(&response).Get("/api/v2/regions")
+
+	return response.Regions, err
+}
+
+// RegionCatalog returns the regions reported by the API, listing them once per client. When they
+// cannot be listed the catalog falls back to NeonFallbackRegions and the listing error is returned
+// alongside it. The fallback is not kept, so the regions are listed again on the next call.
+func (client *NeonApiClient) RegionCatalog(ctx context.Context) (NeonRegionCatalog, error) {
+	if client.regions == nil {
+		return loadRegionCatalog(ctx, client)
+	}
+
+	client.regions.mu.Lock()
+	defer client.regions.mu.Unlock()
+
+	if client.regions.catalog != nil {
+		return *client.regions.catalog, nil
+	}
+
+	catalog, err := loadRegionCatalog(ctx, client)
+	if err == nil {
+		client.regions.catalog = &catalog
+	}
+
+	return catalog, err
+}
+
+func loadRegionCatalog(ctx context.Context, client *NeonApiClient) (NeonRegionCatalog, error) {
+	regions, err := client.RegionList(ctx, NeonApiClientOptions{})
+
+	if err != nil {
+		return NeonRegionCatalog{Regions: NeonFallbackRegions}, err
+	}
+
+	if len(regions) == 0 {
+		return NeonRegionCatalog{Regions: NeonFallbackRegions}, fmt.Errorf("Neon API did not list any region")
+	}
+
+	return NeonRegionCatalog{Regions: regions}, nil
+}
+
+// Lookup returns the region with the given ID, which may be given with or without platform prefix.
+func (catalog NeonRegionCatalog) Lookup(regionID string) (NeonRegion, bool) {
+	canonicalRegionID := CanonicalRegionID(regionID)
+
+	for _, region := range catalog.Regions {
+		if CanonicalRegionID(region.RegionID) == canonicalRegionID {
+			return region, true
+		}
+	}
+
+	return NeonRegion{}, false
+}
+
+// Validate returns an error naming the closest known regions when regionID is not in the catalog.
+func (catalog NeonRegionCatalog) Validate(regionID string) error {
+	if _, ok := catalog.Lookup(regionID); ok {
+		return nil
+	}
+
+	if suggestions := catalog.Suggestions(regionID); len(suggestions) > 0 {
+		return fmt.Errorf("Unknown region `%s`. Did you mean `%s`?", regionID, strings.Join(suggestions, "` or `"))
+	}
+
+	return fmt.Errorf("Unknown region `%s`. Supported regions: `%s`", regionID, strings.Join(catalog.RegionIDs(), "`, `"))
+}
+
+// RegionIDs returns the IDs of the regions in the catalog.
+func (catalog NeonRegionCatalog) RegionIDs() []string {
+	regionIDs := make([]string, 0, len(catalog.Regions))
+	for _, region := range catalog.Regions {
+		regionIDs = append(regionIDs, region.RegionID)
+	}
+	return regionIDs
+}
+
+// Suggestions returns the IDs of the regions closest to regionID, closest first. Regions more than
+// a few edits away are left out.
+func (catalog NeonRegionCatalog) Suggestions(regionID string) []string {
+	const maxDistance = 3
+
+	canonicalRegionID := CanonicalRegionID(regionID)
+
+	type candidate struct {
+		regionID string
+		distance int
+	}
+	var candidates []candidate
+
+	for _, region := range catalog.Regions {
+		distance := editDistance(canonicalRegionID, CanonicalRegionID(region.RegionID))
+		if distance <= maxDistance {
+			candidates = append(candidates, candidate{region.RegionID, distance})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+
+	suggestions := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		suggestions = append(suggestions, candidate.regionID)
+	}
+	return suggestions
+}
+
+// SplitRegionID splits a region ID such as `aws-us-west-2` into its platform and region. The
+// platform is empty when the ID has no known platform prefix, as in `us-west-2`.
+func SplitRegionID(regionID string) (platformID string, region string) {
+	regionID = strings.ToLower(strings.TrimSpace(regionID))
+
+	for _, platformID := range neonPlatformIDs {
+		if strings.HasPrefix(regionID, platformID+"-") {
+			return platformID, strings.TrimPrefix(regionID, platformID+"-")
+		}
+	}
+
+	return "", regionID
+}
+
+// CanonicalRegionID returns the platform-prefixed form of a region ID, which is the form the API
+// reports. IDs without platform prefix are assumed to be on DefaultNeonPlatformID.
+func CanonicalRegionID(regionID string) string {
+	platformID, region := SplitRegionID(regionID)
+	if platformID == "" {
+		platformID = DefaultNeonPlatformID
+	}
+	return platformID + "-" + region
+}
+
+// EquivalentRegionIDs reports whether two region IDs name the same region, such as `us-west-2` and `aws-us-west-2`.
+func EquivalentRegionIDs(a string, b string) bool {
+	return CanonicalRegionID(a) == CanonicalRegionID(b)
+}
+
+// Neon API expects region IDs for input data to be of form `us-west-2` but returns `aws-us-west-2` in response data
+// This supports `aws-us-west-2` for consistency
+func normalizeRegionID(regionID string) (string, error) {
+	_, region := SplitRegionID(regionID)
+
+	if region == "" {
+		return "", fmt.Errorf("Could not parse region ID. Expected to be of form `aws-us-west-2`. given: %s", regionID)
+	}
+
+	return region, nil
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, minInt(current[j-1]+1, previous[j-1]+cost))
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,105 @@
+package neonApi
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"virtual-repetitions/terraform-provider-neon/src/neonApiTest"
+)
+
+// TestRegionCatalog verifies the catalog is listed once from the API and resolves both region ID forms
+func TestRegionCatalog(t *testing.T) {
+	neonApiClient, server := NewFakeNeonApiClientFixture(t)
+
+	for i := 0; i < 2; i++ {
+		catalog, err := neonApiClient.RegionCatalog(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, regionID := range []string{"aws-us-west-2", "us-west-2", "AWS-US-WEST-2"} {
+			region, ok := catalog.Lookup(regionID)
+			if !ok || region.RegionID != "aws-us-west-2" {
+				t.Errorf("Expected %s to resolve to aws-us-west-2, got %+v", regionID, region)
+			}
+		}
+	}
+
+	if count := server.RequestCount(http.MethodGet, "/api/v2/regions"); count != 1 {
+		t.Errorf("Expected regions to be listed once, got %d requests", count)
+	}
+}
+
+// TestRegionCatalogFallsBack verifies the built-in regions are used when the API does not list regions, until it does
+func TestRegionCatalogFallsBack(t *testing.T) {
+	neonApiClient, server := NewFakeNeonApiClientFixture(t)
+
+	server.Inject(neonApiTest.InjectedResponse{
+		Path:       "/api/v2/regions",
+		StatusCode: http.StatusNotFound,
+		Message:    "not found",
+		Times:      1,
+	})
+
+	catalog, err := neonApiClient.RegionCatalog(context.Background())
+
+	if !IsNotFound(err) {
+		t.Errorf("Expected the listing error to be returned, got %v", err)
+	}
+
+	if len(catalog.Regions) != len(NeonFallbackRegions) {
+		t.Errorf("Expected the fallback regions, got %+v", catalog.Regions)
+	}
+
+	catalog, err = neonApiClient.RegionCatalog(context.Background())
+	if err != nil {
+		t.Fatalf("Expected the regions to be listed again, got %v", err)
+	}
+
+	if _, ok := catalog.Lookup("aws-us-west-2"); !ok || server.RequestCount(http.MethodGet, "/api/v2/regions") != 2 {
+		t.Errorf("Expected the listed regions after a failed listing, got %+v", catalog.Regions)
+	}
+}
+
+// TestRegionCatalogValidate verifies unknown regions are rejected with suggestions
+func TestRegionCatalogValidate(t *testing.T) {
+	catalog := NeonRegionCatalog{Regions: NeonFallbackRegions}
+
+	for _, regionID := range []string{"aws-eu-central-1", "eu-central-1"} {
+		if err := catalog.Validate(regionID); err != nil {
+			t.Errorf("Expected %s to be valid, got %s", regionID, err)
+		}
+	}
+
+	err := catalog.Validate("us-west-1")
+	if err == nil || !strings.Contains(err.Error(), "Did you mean `aws-us-west-2`") {
+		t.Errorf("Expected us-west-1 to be rejected with a suggestion, got %v", err)
+	}
+
+	err = catalog.Validate("mars-north-1")
+	if err == nil || !strings.Contains(err.Error(), "Supported regions: `aws-us-east-2`") {
+		t.Errorf("Expected mars-north-1 to be rejected with the supported regions, got %v", err)
+	}
+}
+
+// TestNormalizeRegionID verifies region IDs are sent to the API without platform prefix
+func TestNormalizeRegionID(t *testing.T) {
+	valid := map[string]string{
+		"aws-us-west-2":      "us-west-2",
+		"us-west-2":          "us-west-2",
+		"aws-ap-southeast-1": "ap-southeast-1",
+	}
+	for regionID, expected := range valid {
+		normalized, err := normalizeRegionID(regionID)
+		if err != nil || normalized != expected {
+			t.Errorf("Expected %s to be normalized to %s, got %s %v", regionID, expected, normalized, err)
+		}
+	}
+
+	for _, regionID := range []string{"", "aws-", "  "} {
+		if _, err := normalizeRegionID(regionID); err == nil {
+			t.Errorf("Expected %q to be rejected", regionID)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package neonApi
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/imroc/req/v3"
+)
+
+// NeonApiRetryPolicy controls how failed requests are retried.
+//
+// Requests are retried when Neon answers 429 Too Many Requests, 423 Locked (the project has
+// running operations) or 502/503/504, and on transport errors and 500 responses for idempotent
+// methods. Waits honor the Retry-After header and otherwise use jittered exponential backoff.
+type NeonApiRetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt.
+	MaxRetries int
+	// MinWait is the backoff before the first retry. It doubles with every further retry.
+	MinWait time.Duration
+	// MaxWait caps any single wait, including waits requested through Retry-After.
+	MaxWait time.Duration
+	// Budget caps the total time spent on a request across all of its attempts.
+	Budget time.Duration
+}
+
+func DefaultNeonApiRetryPolicy() NeonApiRetryPolicy {
+	return NeonApiRetryPolicy{
+		MaxRetries: 5,
+		MinWait:    500 * time.Millisecond,
+		MaxWait:    30 * time.Second,
+		Budget:     5 * time.Minute,
+	}
+}
+
+// retryState tracks the retries of a single request.
+type retryState struct {
+	ctx      context.Context
+	policy   NeonApiRetryPolicy
+	start    time.Time
+	attempt  int
+	nextWait time.Duration
+}
+
+func newRetryState(ctx context.Context, policy NeonApiRetryPolicy) *retryState {
+	return &retryState{
+		ctx:    ctx,
+		policy: policy,
+		start:  time.Now(),
+	}
+}
+
+// shouldRetry is the request's retry condition. It also computes the wait before the next
+// attempt so the retry budget can be enforced before committing to a retry.
+func (state *retryState) shouldRetry(resp *req.Response, err error) bool {
+	if err == nil || state.ctx.Err() != nil || !isRetryable(resp, err) {
+		return false
+	}
+
+	wait := state.policy.wait(resp, state.attempt+1)
+	if time.Since(state.start)+wait > state.policy.Budget {
+		return false
+	}
+
+	state.nextWait = wait
+	return true
+}
+
+// waitBeforeRetry is the request's retry hook. It waits here rather than through req's retry
+// interval so that cancelling the context interrupts the wait.
+func (state *retryState) waitBeforeRetry(resp *req.Response, err error) {
+	state.attempt++
+
+	fields := map[string]interface{}{
+		"attempt": state.attempt,
+		"wait":    state.nextWait.String(),
+		"error":   err.Error(),
+	}
+	if resp != nil && resp.Response != nil {
+		fields["status_code"] = resp.StatusCode
+	}
+	tflog.SubsystemDebug(state.ctx, LogSubsystem, "Retrying Neon API request.", fields)
+
+	select {
+	case <-state.ctx.Done():
+	case <-time.After(state.nextWait):
+	}
+}
+
+// isRetryable classifies a failed attempt.
+func isRetryable(resp *req.Response, err error) bool {
+	if resp == nil || resp.Request == nil {
+		return false
+	}
+
+	idempotent := isIdempotentMethod(resp.Request.Method)
+
+	if IsTransportError(err) {
+		// A mutation may have reached the API before the connection failed, so only repeat idempotent requests.
+		return idempotent
+	}
+
+	if IsRateLimited(err) || IsLocked(err) {
+		return true
+	}
+
+	var apiErr NeonApiError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	switch apiErr.StatusCode() {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	case http.StatusInternalServerError:
+		return idempotent
+	}
+	return false
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// wait returns how long to wait before the given retry attempt, starting at 1.
+func (policy NeonApiRetryPolicy) wait(resp *req.Response, attempt int) time.Duration {
+	if resp != nil && resp.Response != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if wait > policy.MaxWait {
+				return policy.MaxWait
+			}
+			return wait
+		}
+	}
+
+	backoff := policy.MinWait
+	for i := 1; i < attempt && backoff < policy.MaxWait; i++ {
+		backoff *= 2
+	}
+	if backoff > policy.MaxWait {
+		backoff = policy.MaxWait
+	}
+
+	// Equal jitter: wait at least half of the backoff so concurrent clients spread out without
+	// retrying immediately.
+	half := backoff / 2
+	if half <= 0 {
+		return backoff
+	}
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
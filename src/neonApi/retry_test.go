@@ -0,0 +1,148 @@
+package neonApi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+	"virtual-repetitions/terraform-provider-neon/src/neonApiTest"
+)
+
+func newRetryTestProject(t *testing.T, neonApiClient NeonApiClient) NeonProject {
+	result, err := neonApiClient.ProjectCreate(context.Background(), NeonProjectCreateData{
+		Project: NeonProjectCreateProjectAttributes{
+			InstanceHandle: "scalable",
+			Name:           "retry-project",
+			PlatformID:     "aws",
+			RegionID:       "aws-us-west-2",
+			Settings:       map[string]string{},
+		},
+	}, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result.Project
+}
+
+// TestRetryOnRateLimitAndLocked verifies 429 and 423 responses are retried until the request succeeds
+func TestRetryOnRateLimitAndLocked(t *testing.T) {
+	neonApiClient, server := NewFakeNeonApiClientFixture(t)
+	project := newRetryTestProject(t, neonApiClient)
+	projectPath := fmt.Sprintf("/api/v1/projects/%s", project.ID)
+
+	server.Inject(neonApiTest.InjectedResponse{
+		Path:       projectPath,
+		StatusCode: http.StatusTooManyRequests,
+		Message:    "rate limit exceeded",
+		Header:     http.Header{"Retry-After": []string{"0"}},
+		Times:      1,
+	})
+	server.Inject(neonApiTest.InjectedResponse{
+		Path:       projectPath,
+		StatusCode: http.StatusLocked,
+		Message:    "project already has running operations",
+		Times:      1,
+	})
+
+	_, err := neonApiClient.ProjectUpdate(context.Background(), project.ID, NeonProjectUpdateData{
+		Project: NeonProjectUpdateProjectAttributes{Name: "renamed"},
+	}, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count := server.RequestCount(http.MethodPatch, projectPath); count != 3 {
+		t.Errorf("Expected 3 attempts, got %d", count)
+	}
+}
+
+// TestRetrySkipsClientErrors verifies 4xx responses other than 423 and 429 are not retried
+func TestRetrySkipsClientErrors(t *testing.T) {
+	neonApiClient, server := NewFakeNeonApiClientFixture(t)
+
+	_, err := neonApiClient.ProjectRead(context.Background(), "missing-project", NewDefaultNeonApiClientOptionsFixture())
+	if err == nil {
+		t.Fatal("Expected to receive error, got nil error instead.")
+	}
+
+	if count := server.RequestCount(http.MethodGet, "/api/v1/projects/missing-project"); count != 1 {
+		t.Errorf("Expected a single attempt, got %d", count)
+	}
+}
+
+// TestRetryServerErrorsOnlyForIdempotentMethods verifies 500 responses are only retried for reads
+func TestRetryServerErrorsOnlyForIdempotentMethods(t *testing.T) {
+	neonApiClient, server := NewFakeNeonApiClientFixture(t)
+
+	server.Inject(neonApiTest.InjectedResponse{
+		StatusCode: http.StatusInternalServerError,
+		Message:    "internal error",
+	})
+
+	neonApiClient.ProjectRead(context.Background(), "some-project", NewDefaultNeonApiClientOptionsFixture())
+	if count := server.RequestCount(http.MethodGet, "/api/v1/projects/some-project"); count != 4 {
+		t.Errorf("Expected GET to be attempted 4 times, got %d", count)
+	}
+
+	neonApiClient.ProjectDelete(context.Background(), "some-project", NewDefaultNeonApiClientOptionsFixture())
+	if count := server.RequestCount(http.MethodPost, "/api/v1/projects/some-project/delete"); count != 1 {
+		t.Errorf("Expected POST to be attempted once, got %d", count)
+	}
+}
+
+// TestRetryStopsWhenBudgetIsSpent verifies retries stop once the total budget would be exceeded
+func TestRetryStopsWhenBudgetIsSpent(t *testing.T) {
+	neonApiClient, server := NewFakeNeonApiClientFixture(t)
+	neonApiClient.SetRetryPolicy(NeonApiRetryPolicy{
+		MaxRetries: 10,
+		MinWait:    time.Millisecond,
+		MaxWait:    time.Second,
+		Budget:     50 * time.Millisecond,
+	})
+
+	server.Inject(neonApiTest.InjectedResponse{
+		StatusCode: http.StatusTooManyRequests,
+		Message:    "rate limit exceeded",
+		Header:     http.Header{"Retry-After": []string{"1"}},
+	})
+
+	start := time.Now()
+	_, err := neonApiClient.ProjectRead(context.Background(), "some-project", NewDefaultNeonApiClientOptionsFixture())
+	if err == nil {
+		t.Fatal("Expected to receive error, got nil error instead.")
+	}
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected retries to stop within the budget, took %s", elapsed)
+	}
+
+	if count := server.RequestCount(http.MethodGet, "/api/v1/projects/some-project"); count != 1 {
+		t.Errorf("Expected a single attempt, got %d", count)
+	}
+}
+
+// TestRetryPolicyWait verifies backoff stays within bounds and Retry-After is honored
+func TestRetryPolicyWait(t *testing.T) {
+	policy := NeonApiRetryPolicy{MinWait: 100 * time.Millisecond, MaxWait: time.Second}
+
+	for attempt := 1; attempt <= 10; attempt++ {
+		wait := policy.wait(nil, attempt)
+		if wait < 50*time.Millisecond || wait > time.Second {
+			t.Errorf("Expected wait for attempt %d to be within bounds, got %s", attempt, wait)
+		}
+	}
+
+	if wait, ok := parseRetryAfter("3"); !ok || wait != 3*time.Second {
+		t.Errorf("Expected Retry-After in seconds to be parsed, got %s", wait)
+	}
+
+	date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if wait, ok := parseRetryAfter(date); !ok || wait < 59*time.Minute {
+		t.Errorf("Expected Retry-After date to be parsed, got %s", wait)
+	}
+
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Errorf("Expected invalid Retry-After to be ignored")
+	}
+}
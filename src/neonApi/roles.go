@@ -0,0 +1,147 @@
+package neonApi
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+type NeonRole struct {
+	BranchID  string    `json:"branch_id"`
+	Name      string    `json:"name"`
+	Password  string    `json:"password"`
+	Protected bool      `json:"protected"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type NeonRoleMutationResult struct {
+	Role     NeonRole
+	Response NeonRoleMutationSuccessResponse
+}
+
+// NeonRoleMutationSuccessResponse is returned when a role is created, deleted or has its password reset.
+// The role only includes its password when it was created or reset.
+type NeonRoleMutationSuccessResponse struct {
+	Role       NeonRole        `json:"role"`
+	Operations []NeonOperation `json:"operations"`
+}
+
+type NeonRoleReadSuccessResponse struct {
+	Role NeonRole `json:"role"`
+}
+
+type NeonRoleListSuccessResponse struct {
+	Roles []NeonRole `json:"roles"`
+}
+
+type NeonRolePasswordSuccessResponse struct {
+	Password string `json:"password"`
+}
+
+type NeonRoleCreateData struct {
+	Role NeonRoleCreateRoleAttributes `json:"role"`
+}
+
+type NeonRoleCreateRoleAttributes struct {
+	Name string `json:"name"`
+}
+
+func rolePath(projectID string, branchID string, roleName string) string {
+	return fmt.Sprintf("/api/v2/projects/%s/branches/%s/roles/%s", projectID, branchID, url.PathEscape(roleName))
+}
+
+func (client *NeonApiClient) RoleCreate(ctx context.Context, projectID string, branchID string, data NeonRoleCreateData, options NeonApiClientOptions) (NeonRoleMutationResult, error) {
+	unlock, err := client.lockProject(ctx, projectID)
+	if err != nil {
+		return NeonRoleMutationResult{}, err
+	}
+	defer unlock()
+
+	var response NeonRoleMutationSuccessResponse
+
+	_, err = client.newRequest(ctx, options).SetResult(&response).SetBody(data).Post(fmt.Sprintf("/api/v2/projects/%s/branches/%s/roles", projectID, branchID))
+
+	if err != nil {
+		return NeonRoleMutationResult{}, err
+	}
+
+	result := NeonRoleMutationResult{
+		Role:     response.Role,
+		Response: response,
+	}
+
+	err = client.OperationsWait(ctx, projectID, response.Operations, options)
+
+	return result, err
+}
+
+// RoleRead returns the role without its password. See RoleRevealPassword.
+func (client *NeonApiClient) RoleRead(ctx context.Context, projectID string, branchID string, roleName string, options NeonApiClientOptions) (NeonRole, error) {
+	var response NeonRoleReadSuccessResponse
+
+	_, err := client.newRequest(ctx, options).SetResult(&response).Get(rolePath(projectID, branchID, roleName))
+
+	return response.Role, err
+}
+
+func (client *NeonApiClient) RoleList(ctx context.Context, projectID string, branchID string, options NeonApiClientOptions) ([]NeonRole, error) {
+	var response NeonRoleListSuccessResponse
+
+	_, err := client.newRequest(ctx, options).SetResult(&response).Get(fmt.Sprintf("/api/v2/projects/%s/branches/%s/roles", projectID, branchID))
+
+	return response.Roles, err
+}
+
+func (client *NeonApiClient) RoleDelete(ctx context.Context, projectID string, branchID string, roleName string, options NeonApiClientOptions) error {
+	unlock, err := client.lockProject(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	var response NeonRoleMutationSuccessResponse
+
+	_, err = client.newRequest(ctx, options).SetResult(&response).Delete(rolePath(projectID, branchID, roleName))
+
+	if err != nil {
+		return err
+	}
+
+	return client.OperationsWait(ctx, projectID, response.Operations, options)
+}
+
+// RoleResetPassword replaces the password of the role with a new one generated by Neon.
+func (client *NeonApiClient) RoleResetPassword(ctx context.Context, projectID string, branchID string, roleName string, options NeonApiClientOptions) (NeonRoleMutationResult, error) {
+	unlock, err := client.lockProject(ctx, projectID)
+	if err != nil {
+		return NeonRoleMutationResult{}, err
+	}
+	defer unlock()
+
+	var response NeonRoleMutationSuccessResponse
+
+	_, err = client.newRequest(ctx, options).SetResult(&response).Post(rolePath(projectID, branchID, roleName) + "/reset_password")
+
+	if err != nil {
+		return NeonRoleMutationResult{}, err
+	}
+
+	result := NeonRoleMutationResult{
+		Role:     response.Role,
+		Response: response,
+	}
+
+	err = client.OperationsWait(ctx, projectID, response.Operations, options)
+
+	return result, err
+}
+
+func (client *NeonApiClient) RoleRevealPassword(ctx context.Context, projectID string, branchID string, roleName string, options NeonApiClientOptions) (string, error) {
+	var response NeonRolePasswordSuccessResponse
+
+	_, err := client.newRequest(ctx, options).SetResult(&response).Get(rolePath(projectID, branchID, roleName) + "/reveal_password")
+
+	return response.Password, err
+}
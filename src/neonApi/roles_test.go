@@ -0,0 +1,123 @@
+package neonApi
+
+import (
+	"context"
+	"testing"
+)
+
+func newRoleFixture(t *testing.T, projectID string, branchID string) NeonRole {
+	neonApiClient := NewNeonApiClientFixture()
+
+	result, err := neonApiClient.RoleCreate(context.Background(), projectID, branchID, NeonRoleCreateData{
+		Role: NeonRoleCreateRoleAttributes{Name: "test_role"},
+	}, NewDefaultNeonApiClientOptionsFixture())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result.Role
+}
+
+// TestRoleCreate verifies Neon Postgres role can be created with a generated password
+func TestRoleCreate(t *testing.T) {
+	projectFixture := NewProjectFixture(t, true)
+	branchFixture := NewBranchFixture(t, projectFixture.ID)
+
+	neonApiClient := NewNeonApiClientFixture()
+
+	result, err := neonApiClient.SetDebug(false).RoleCreate(context.Background(), projectFixture.ID, branchFixture.ID, NeonRoleCreateData{
+		Role: NeonRoleCreateRoleAttributes{Name: "app_user"},
+	}, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Error(err)
+	}
+
+	if result.Role.Name != "app_user" || result.Role.BranchID != branchFixture.ID {
+		t.Errorf("Expected role app_user of branch %s, got %+v", branchFixture.ID, result.Role)
+	}
+
+	if result.Role.Password == "" {
+		t.Errorf("Expected created role to include its password")
+	}
+}
+
+// TestRoleReadAndList verifies Neon Postgres role can be read and listed
+func TestRoleReadAndList(t *testing.T) {
+	projectFixture := NewProjectFixture(t, true)
+	branchFixture := NewBranchFixture(t, projectFixture.ID)
+	roleFixture := newRoleFixture(t, projectFixture.ID, branchFixture.ID)
+
+	neonApiClient := NewNeonApiClientFixture()
+
+	role, err := neonApiClient.SetDebug(false).RoleRead(context.Background(), projectFixture.ID, branchFixture.ID, roleFixture.Name, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Error(err)
+	}
+
+	if role.Name != roleFixture.Name {
+		t.Errorf("Expected role %s, got %s", roleFixture.Name, role.Name)
+	}
+
+	roles, err := neonApiClient.RoleList(context.Background(), projectFixture.ID, branchFixture.ID, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Error(err)
+	}
+
+	found := false
+	for _, role := range roles {
+		if role.Name == roleFixture.Name {
+			found = true
+		}
+	}
+
+	if !found {
+		t.Errorf("Expected role %s to be listed, got %+v", roleFixture.Name, roles)
+	}
+}
+
+// TestRolePasswords verifies Neon Postgres role password can be reset and revealed
+func TestRolePasswords(t *testing.T) {
+	projectFixture := NewProjectFixture(t, true)
+	branchFixture := NewBranchFixture(t, projectFixture.ID)
+	roleFixture := newRoleFixture(t, projectFixture.ID, branchFixture.ID)
+
+	neonApiClient := NewNeonApiClientFixture()
+
+	password, err := neonApiClient.SetDebug(false).RoleRevealPassword(context.Background(), projectFixture.ID, branchFixture.ID, roleFixture.Name, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Error(err)
+	}
+
+	if password != roleFixture.Password {
+		t.Errorf("Expected revealed password to match the password returned on creation")
+	}
+
+	result, err := neonApiClient.RoleResetPassword(context.Background(), projectFixture.ID, branchFixture.ID, roleFixture.Name, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Error(err)
+	}
+
+	if result.Role.Password == "" || result.Role.Password == roleFixture.Password {
+		t.Errorf("Expected password to be replaced")
+	}
+}
+
+// TestRoleDelete verifies Neon Postgres role can be deleted
+func TestRoleDelete(t *testing.T) {
+	projectFixture := NewProjectFixture(t, true)
+	branchFixture := NewBranchFixture(t, projectFixture.ID)
+	roleFixture := newRoleFixture(t, projectFixture.ID, branchFixture.ID)
+
+	neonApiClient := NewNeonApiClientFixture()
+
+	err := neonApiClient.SetDebug(false).RoleDelete(context.Background(), projectFixture.ID, branchFixture.ID, roleFixture.Name, NewDefaultNeonApiClientOptionsFixture())
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = neonApiClient.RoleRead(context.Background(), projectFixture.ID, branchFixture.ID, roleFixture.Name, NewDefaultNeonApiClientOptionsFixture())
+	if !IsNotFound(err) {
+		t.Errorf("Expected deleted role to be not found, got %v", err)
+	}
+}
@@ -1,13 +1,14 @@
 package neonApi
 
 import (
+	"context"
 	"testing"
 )
 
 func ProjectFixtureDelete(t *testing.T, projectID string) {
 	neonApiClient := NewNeonApiClientFixture()
 
-	err := neonApiClient.ProjectDelete(projectID, NewDefaultNeonApiClientOptionsFixture())
+	err := neonApiClient.ProjectDelete(context.Background(), projectID, NewDefaultNeonApiClientOptionsFixture())
 
 	if err != nil {
 		t.Errorf("Could not delete project. err: %s", err)
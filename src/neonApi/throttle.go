@@ -0,0 +1,145 @@
+package neonApi
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/imroc/req/v3"
+)
+
+// NeonApiThrottle limits the requests a client sends to the Neon API. Every attempt of a request counts,
+// including retries. Zero values leave the respective limit off.
+type NeonApiThrottle struct {
+	// MaxConcurrentRequests caps the number of requests in flight at once.
+	MaxConcurrentRequests int
+	// RequestsPerSecond caps the rate at which requests are sent. Bursts of up to one second worth of
+	// requests are allowed.
+	RequestsPerSecond float64
+}
+
+// requestThrottle enforces a NeonApiThrottle across all copies of a client.
+type requestThrottle struct {
+	mu      sync.Mutex
+	logging *requestLogging
+	slots   chan struct{}
+	bucket  *tokenBucket
+}
+
+// set replaces the limits of the throttle. Requests already waiting keep the limits they started with.
+func (t *requestThrottle) set(throttle NeonApiThrottle) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.slots = nil
+	if throttle.MaxConcurrentRequests > 0 {
+		t.slots = make(chan struct{}, throttle.MaxConcurrentRequests)
+	}
+	t.bucket = nil
+	if throttle.RequestsPerSecond > 0 {
+		t.bucket = newTokenBucket(throttle.RequestsPerSecond, time.Now())
+	}
+}
+
+// wait blocks until the request may be sent, or until ctx is done. The returned function must be called
+// once the request has completed.
+func (t *requestThrottle) wait(ctx context.Context) (func(), error) {
+	t.mu.Lock()
+	slots, bucket := t.slots, t.bucket
+	t.mu.Unlock()
+
+	// The rate limit is waited for first, so that requests waiting for it do not hold a slot that a
+	// request ready to be sent could use.
+	if bucket != nil {
+		if delay := bucket.reserve(time.Now()); delay > 0 {
+			tflog.SubsystemDebug(ctx, LogSubsystem, "Waiting for the Neon API request rate limit.", map[string]interface{}{
+				"requests_per_second": bucket.rate,
+				"wait_ms":             delay.Milliseconds(),
+			})
+
+			timer := time.NewTimer(delay)
+			defer timer.Stop()
+
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				bucket.cancel()
+				return nil, ctx.Err()
+			}
+		}
+	}
+
+	if slots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case slots <- struct{}{}:
+	default:
+		tflog.SubsystemDebug(ctx, LogSubsystem, "Waiting for a Neon API request slot.", map[string]interface{}{"max_concurrent_requests": cap(slots)})
+
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	return func() { <-slots }, nil
+}
+
+// throttleRequests is a round trip middleware holding every attempt of a request until the throttle
+// lets it through.
+func (t *requestThrottle) throttleRequests(rt req.RoundTripper) req.RoundTripFunc {
+	return func(r *req.Request) (*req.Response, error) {
+		release, err := t.wait(t.logging.newLogContext(r.Context()))
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+
+		return rt.RoundTrip(r)
+	}
+}
+
+// tokenBucket is a token bucket refilled at rate tokens per second, holding at most burst tokens. Tokens
+// may be reserved ahead of time, in which case the caller waits until the bucket has refilled.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, now time.Time) *tokenBucket {
+	burst := math.Max(1, math.Floor(rate))
+	return &tokenBucket{rate: rate, burst: burst, tokens: burst, last: now}
+}
+
+// reserve takes a token and returns how long to wait until it is available.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if now.After(b.last) {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+	}
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a token reserved by a request that was not sent.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
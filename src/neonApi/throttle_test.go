@@ -0,0 +1,253 @@
+package neonApi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/imroc/req/v3"
+)
+
+// TestTokenBucket verifies the bucket allows a burst of one second worth of requests and spaces out the rest
+func TestTokenBucket(t *testing.T) {
+	now := time.Unix(0, 0)
+	bucket := newTokenBucket(2, now)
+
+	for i := 0; i < 2; i++ {
+		if delay := bucket.reserve(now); delay != 0 {
+			t.Fatalf("Expected request %d of the burst to be sent right away, got a wait of %v", i, delay)
+		}
+	}
+	if delay := bucket.reserve(now); delay != 500*time.Millisecond {
+		t.Fatalf("Expected the third request to wait 500ms, got %v", delay)
+	}
+	if delay := bucket.reserve(now); delay != time.Second {
+		t.Fatalf("Expected the fourth request to wait 1s, got %v", delay)
+	}
+
+	// Cancelled reservations hand their token to the next request.
+	bucket.cancel()
+	if delay := bucket.reserve(now.Add(250 * time.Millisecond)); delay != 750*time.Millisecond {
+		t.Fatalf("Expected the request after a cancellation to wait 750ms, got %v", delay)
+	}
+
+	// An idle bucket refills up to its burst only.
+	later := now.Add(time.Minute)
+	for i := 0; i < 2; i++ {
+		if delay := bucket.reserve(later); delay != 0 {
+			t.Fatalf("Expected request %d after idling to be sent right away, got a wait of %v", i, delay)
+		}
+	}
+	if delay := bucket.reserve(later); delay != 500*time.Millisecond {
+		t.Fatalf("Expected the burst after idling to be capped, got a wait of %v", delay)
+	}
+}
+
+// TestTokenBucketSlowRate verifies rates below one request per second still allow a single request right away
+func TestTokenBucketSlowRate(t *testing.T) {
+	now := time.Unix(0, 0)
+	bucket := newTokenBucket(0.5, now)
+
+	if delay := bucket.reserve(now); delay != 0 {
+		t.Fatalf("Expected the first request to be sent right away, got a wait of %v", delay)
+	}
+	if delay := bucket.reserve(now); delay != 2*time.Second {
+		t.Fatalf("Expected the second request to wait 2s, got %v", delay)
+	}
+}
+
+func newThrottleTestClient(t *testing.T, handler http.HandlerFunc, throttle NeonApiThrottle) NeonApiClient {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	neonApiClient := NewNeonApiClient(req.C(), "secret-api-key")
+	neonApiClient.SetApiURL(server.URL).SetRetryPolicy(NeonApiRetryPolicy{}).SetThrottle(throttle)
+	return neonApiClient
+}
+
+func writeThrottleTestProject(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	io.WriteString(w, `{"project":{"id":"some-project"}}`)
+}
+
+// TestClientLimitsConcurrentRequests verifies no more than MaxConcurrentRequests requests are in flight at once
+func TestClientLimitsConcurrentRequests(t *testing.T) {
+	var inFlight, maxInFlight int32
+	neonApiClient := newThrottleTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			seen := atomic.LoadInt32(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		writeThrottleTestProject(w)
+	}, NeonApiThrottle{MaxConcurrentRequests: 2})
+
+	var wg sync.WaitGroup
+	errs := make([]error, 6)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = neonApiClient.ProjectRead(context.Background(), "some-project", NewDefaultNeonApiClientOptionsFixture())
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("Expected request %d to succeed, got %v", i, err)
+		}
+	}
+	if maxInFlight > 2 {
+		t.Errorf("Expected at most 2 requests in flight, got %d", maxInFlight)
+	}
+}
+
+// TestClientLimitsRequestRate verifies requests beyond the burst are spaced out by the rate limit
+func TestClientLimitsRequestRate(t *testing.T) {
+	neonApiClient := newThrottleTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeThrottleTestProject(w)
+	}, NeonApiThrottle{RequestsPerSecond: 20})
+
+	start := time.Now()
+	for i := 0; i < 22; i++ {
+		if _, err := neonApiClient.ProjectRead(context.Background(), "some-project", NewDefaultNeonApiClientOptionsFixture()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The first 20 requests are a burst, the other two wait 50ms each.
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("Expected the requests to take at least 90ms, took %v", elapsed)
+	}
+}
+
+// TestClientCombinesThrottleLimits verifies both limits apply together without slowing requests down further than either does
+func TestClientCombinesThrottleLimits(t *testing.T) {
+	var inFlight, maxInFlight int32
+	neonApiClient := newThrottleTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			seen := atomic.LoadInt32(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		writeThrottleTestProject(w)
+	}, NeonApiThrottle{MaxConcurrentRequests: 2, RequestsPerSecond: 20})
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	errs := make([]error, 24)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = neonApiClient.ProjectRead(context.Background(), "some-project", NewDefaultNeonApiClientOptionsFixture())
+		}(i)
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("Expected request %d to succeed, got %v", i, err)
+		}
+	}
+	if maxInFlight > 2 {
+		t.Errorf("Expected at most 2 requests in flight, got %d", maxInFlight)
+	}
+	// The four requests beyond the burst wait 200ms for the rate limit, the slots alone take 120ms.
+	if elapsed < 190*time.Millisecond || elapsed > time.Second {
+		t.Errorf("Expected the requests to take about 200ms, took %v", elapsed)
+	}
+}
+
+// TestThrottleWaitsForRateBeforeSlot verifies a request waiting for the rate limit leaves the request slots free
+func TestThrottleWaitsForRateBeforeSlot(t *testing.T) {
+	throttle := &requestThrottle{logging: &requestLogging{}}
+	throttle.set(NeonApiThrottle{MaxConcurrentRequests: 1, RequestsPerSecond: 1})
+
+	release, err := throttle.wait(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := throttle.wait(ctx)
+		done <- err
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	if len(throttle.slots) != 0 {
+		t.Errorf("Expected the request waiting for the rate limit not to hold a slot")
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the waiting request to fail with %v, got %v", context.Canceled, err)
+	}
+}
+
+// TestClientThrottleHonorsContext verifies a request waiting for a slot is logged and gives up when its context is done
+func TestClientThrottleHonorsContext(t *testing.T) {
+	release := make(chan struct{})
+	neonApiClient := newThrottleTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		writeThrottleTestProject(w)
+	}, NeonApiThrottle{MaxConcurrentRequests: 1})
+	defer close(release)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		neonApiClient.ProjectRead(context.Background(), "some-project", NewDefaultNeonApiClientOptionsFixture())
+	}()
+
+	// Wait for the first request to take the only slot.
+	for neonApiClient.throttle.slots == nil || len(neonApiClient.throttle.slots) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	var output bytes.Buffer
+	ctx, cancel := context.WithTimeout(tflogtest.RootLogger(context.Background(), &output), 20*time.Millisecond)
+	defer cancel()
+	_, err := neonApiClient.ProjectRead(ctx, "some-project", NewDefaultNeonApiClientOptionsFixture())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the waiting request to fail with %v, got %v", context.DeadlineExceeded, err)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waits := 0
+	for _, entry := range entries {
+		if entry["@message"] == "Waiting for a Neon API request slot." && entry["max_concurrent_requests"] == float64(1) {
+			waits++
+		}
+	}
+	if waits != 1 {
+		t.Errorf("Expected the wait for a request slot to be logged once, got %d entries: %v", waits, entries)
+	}
+
+	release <- struct{}{}
+	<-done
+}
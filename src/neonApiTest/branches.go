@@ -0,0 +1,266 @@
+package neonApiTest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// Branch mirrors a branch object of the Neon API v2.
+type Branch struct {
+	ID              string    `json:"id"`
+	ProjectID       string    `json:"project_id"`
+	ParentID        string    `json:"parent_id,omitempty"`
+	ParentLsn       string    `json:"parent_lsn,omitempty"`
+	ParentTimestamp string    `json:"parent_timestamp,omitempty"`
+	Name            string    `json:"name"`
+	CurrentState    string    `json:"current_state"`
+	PendingState    string    `json:"pending_state,omitempty"`
+	LogicalSize     int64     `json:"logical_size"`
+	Protected       bool      `json:"protected"`
+	Default         bool      `json:"default"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+type branchResponse struct {
+	Branch     Branch      `json:"branch"`
+	Endpoints  []Endpoint  `json:"endpoints,omitempty"`
+	Operations []Operation `json:"operations"`
+}
+
+type branchListResponse struct {
+	Branches []Branch `json:"branches"`
+}
+
+type branchCreateRequest struct {
+	Branch struct {
+		ParentID        string `json:"parent_id"`
+		Name            string `json:"name"`
+		ParentLsn       string `json:"parent_lsn"`
+		ParentTimestamp string `json:"parent_timestamp"`
+		Protected       bool   `json:"protected"`
+	} `json:"branch"`
+	Endpoints []struct {
+		Type string `json:"type"`
+	} `json:"endpoints"`
+}
+
+type branchUpdateRequest struct {
+	Branch struct {
+		Name      string `json:"name"`
+		Protected *bool  `json:"protected"`
+	} `json:"branch"`
+}
+
+// Branches returns copies of the branches of a project ordered by creation.
+func (s *Server) Branches(projectID string) []Branch {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.projectBranches(projectID)
+}
+
+func (s *Server) projectBranches(projectID string) []Branch {
+	branches := []Branch{}
+	for _, branch := range s.branches {
+		if branch.ProjectID == projectID {
+			branches = append(branches, s.branchSnapshot(branch))
+		}
+	}
+	sort.Slice(branches, func(i, j int) bool { return branches[i].ID < branches[j].ID })
+	return branches
+}
+
+// branchInsert stores a new branch of the project, filling in the fields the API computes.
+func (s *Server) branchInsert(project *Project, branch Branch) *Branch {
+	now := time.Now().UTC()
+
+	branch.ID = s.newID("br")
+	branch.ProjectID = project.ID
+	branch.CreatedAt = now
+	branch.UpdatedAt = now
+	branch.LogicalSize = 30 * 1024 * 1024
+	if branch.Name == "" {
+		branch.Name = branch.ID
+	}
+	if branch.ParentID != "" && branch.ParentLsn == "" && branch.ParentTimestamp == "" {
+		branch.ParentLsn = fmt.Sprintf("0/%X", 0x1F00000+s.nextID*0x100)
+	}
+
+	s.branches[branch.ID] = &branch
+	return &branch
+}
+
+func (s *Server) branchSnapshot(branch *Branch) Branch {
+	snapshot := *branch
+	snapshot.CurrentState = "ready"
+	if s.branchBusy(branch.ID) {
+		snapshot.CurrentState = "init"
+		snapshot.PendingState = "ready"
+	}
+	return snapshot
+}
+
+func (s *Server) projectBranch(w http.ResponseWriter, project *Project, branchID string) (*Branch, bool) {
+	branch, ok := s.branches[branchID]
+	if !ok || branch.ProjectID != project.ID {
+		writeNotFound(w, "branch")
+		return nil, false
+	}
+	return branch, true
+}
+
+func (s *Server) defaultBranch(projectID string) *Branch {
+	for _, branch := range s.branches {
+		if branch.ProjectID == projectID && branch.Default {
+			return branch
+		}
+	}
+	return nil
+}
+
+// routeBranches handles /api/v2/projects/{project_id}/branches/...
+func (s *Server) routeBranches(w http.ResponseWriter, r *http.Request, project *Project, segments []string, body []byte) {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, branchListResponse{Branches: s.projectBranches(project.ID)})
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		s.branchCreate(w, project, body)
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		if branch, ok := s.projectBranch(w, project, segments[0]); ok {
+			writeJSON(w, http.StatusOK, branchResponse{Branch: s.branchSnapshot(branch)})
+		}
+	case len(segments) == 1 && r.Method == http.MethodPatch:
+		s.branchUpdate(w, project, segments[0], body)
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		s.branchDelete(w, project, segments[0])
+	default:
+		writeNotFound(w, "route")
+	}
+}
+
+func (s *Server) branchCreate(w http.ResponseWriter, project *Project, body []byte) {
+	var data branchCreateRequest
+	if !decodeBody(w, body, &data) {
+		return
+	}
+
+	if data.Branch.ParentLsn != "" && data.Branch.ParentTimestamp != "" {
+		writeError(w, http.StatusBadRequest, "", "only one of parent_lsn and parent_timestamp can be specified")
+		return
+	}
+
+	if data.Branch.ParentTimestamp != "" {
+		if _, err := time.Parse(time.RFC3339, data.Branch.ParentTimestamp); err != nil {
+			writeError(w, http.StatusBadRequest, "", fmt.Sprintf("parent_timestamp is invalid: %s", data.Branch.ParentTimestamp))
+			return
+		}
+	}
+
+	parentID := data.Branch.ParentID
+	if parentID == "" {
+		parentID = s.defaultBranch(project.ID).ID
+	}
+	if parent, ok := s.branches[parentID]; !ok || parent.ProjectID != project.ID {
+		writeError(w, http.StatusBadRequest, "", fmt.Sprintf("parent branch not found: %s", parentID))
+		return
+	}
+
+	for _, branch := range s.projectBranches(project.ID) {
+		if data.Branch.Name != "" && branch.Name == data.Branch.Name {
+			writeError(w, http.StatusConflict, "", fmt.Sprintf("branch with name %s already exists", data.Branch.Name))
+			return
+		}
+	}
+
+	branch := s.branchInsert(project, Branch{
+		ParentID:        parentID,
+		ParentLsn:       data.Branch.ParentLsn,
+		ParentTimestamp: data.Branch.ParentTimestamp,
+		Name:            data.Branch.Name,
+		Protected:       data.Branch.Protected,
+	})
+
+	// A branch starts with a copy of the roles and databases of its parent.
+	for _, role := range s.roles[parentID] {
+		copied := *role
+		copied.BranchID = branch.ID
+		s.roles[branch.ID] = append(s.roles[branch.ID], &copied)
+	}
+	for _, database := range s.databases[parentID] {
+		s.databaseInsert(branch, database.Name, database.OwnerName)
+	}
+
+	response := branchResponse{
+		Operations: []Operation{s.scheduleOperation(project.ID, branch.ID, "", "create_branch")},
+	}
+
+	for _, endpointData := range data.Endpoints {
+		endpoint := s.endpointInsert(project, Endpoint{BranchID: branch.ID, Type: endpointData.Type})
+		response.Endpoints = append(response.Endpoints, *endpoint)
+		response.Operations = append(response.Operations, s.scheduleOperation(project.ID, branch.ID, endpoint.ID, "start_compute"))
+	}
+
+	response.Branch = s.branchSnapshot(branch)
+	writeJSON(w, http.StatusCreated, response)
+}
+
+func (s *Server) branchUpdate(w http.ResponseWriter, project *Project, branchID string, body []byte) {
+	branch, ok := s.projectBranch(w, project, branchID)
+	if !ok {
+		return
+	}
+
+	var data branchUpdateRequest
+	if !decodeBody(w, body, &data) {
+		return
+	}
+
+	if data.Branch.Name != "" {
+		branch.Name = data.Branch.Name
+	}
+	if data.Branch.Protected != nil {
+		branch.Protected = *data.Branch.Protected
+	}
+	branch.UpdatedAt = time.Now().UTC()
+
+	writeJSON(w, http.StatusOK, branchResponse{Branch: s.branchSnapshot(branch), Operations: []Operation{}})
+}
+
+func (s *Server) branchDelete(w http.ResponseWriter, project *Project, branchID string) {
+	branch, ok := s.projectBranch(w, project, branchID)
+	if !ok {
+		return
+	}
+
+	if branch.Default {
+		writeError(w, http.StatusBadRequest, "", "cannot delete the default branch")
+		return
+	}
+
+	for _, child := range s.branches {
+		if child.ParentID == branch.ID {
+			writeError(w, http.StatusBadRequest, "", fmt.Sprintf("branch has children: %s", child.ID))
+			return
+		}
+	}
+
+	response := branchResponse{Operations: []Operation{}}
+
+	for endpointID, endpoint := range s.endpoints {
+		if endpoint.BranchID == branch.ID {
+			response.Operations = append(response.Operations, s.scheduleOperation(project.ID, branch.ID, endpointID, "suspend_compute"))
+			delete(s.endpoints, endpointID)
+		}
+	}
+	response.Operations = append(response.Operations, s.scheduleOperation(project.ID, branch.ID, "", "delete_timeline"))
+
+	delete(s.branches, branch.ID)
+	delete(s.roles, branch.ID)
+	delete(s.databases, branch.ID)
+
+	response.Branch = *branch
+	writeJSON(w, http.StatusOK, response)
+}
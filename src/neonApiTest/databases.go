@@ -0,0 +1,174 @@
+package neonApiTest
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Database mirrors a database object of the Neon API v2.
+type Database struct {
+	ID        int       `json:"id"`
+	BranchID  string    `json:"branch_id"`
+	Name      string    `json:"name"`
+	OwnerName string    `json:"owner_name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type databaseResponse struct {
+	Database   Database    `json:"database"`
+	Operations []Operation `json:"operations"`
+}
+
+type databaseListResponse struct {
+	Databases []Database `json:"databases"`
+}
+
+type databaseMutationRequest struct {
+	Database struct {
+		Name      string `json:"name"`
+		OwnerName string `json:"owner_name"`
+	} `json:"database"`
+}
+
+// Databases returns copies of the databases of a branch.
+func (s *Server) Databases(branchID string) []Database {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	databases := []Database{}
+	for _, database := range s.databases[branchID] {
+		databases = append(databases, *database)
+	}
+	return databases
+}
+
+func (s *Server) databaseInsert(branch *Branch, name string, ownerName string) *Database {
+	now := time.Now().UTC()
+	database := &Database{
+		ID:        s.nextNumericID(),
+		BranchID:  branch.ID,
+		Name:      name,
+		OwnerName: ownerName,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	s.databases[branch.ID] = append(s.databases[branch.ID], database)
+	return database
+}
+
+func (s *Server) branchDatabase(branchID string, name string) *Database {
+	for _, database := range s.databases[branchID] {
+		if database.Name == name {
+			return database
+		}
+	}
+	return nil
+}
+
+// routeDatabases handles /api/v2/projects/{project_id}/branches/{branch_id}/databases/...
+func (s *Server) routeDatabases(w http.ResponseWriter, r *http.Request, project *Project, branchID string, segments []string, body []byte) {
+	branch, ok := s.projectBranch(w, project, branchID)
+	if !ok {
+		return
+	}
+
+	if len(segments) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			databases := []Database{}
+			for _, database := range s.databases[branch.ID] {
+				databases = append(databases, *database)
+			}
+			writeJSON(w, http.StatusOK, databaseListResponse{Databases: databases})
+		case http.MethodPost:
+			s.databaseCreate(w, branch, body)
+		default:
+			writeNotFound(w, "route")
+		}
+		return
+	}
+
+	database := s.branchDatabase(branch.ID, segments[0])
+	if len(segments) != 1 || database == nil {
+		writeNotFound(w, "database")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, databaseResponse{Database: *database})
+	case http.MethodPatch:
+		s.databaseUpdate(w, branch, database, body)
+	case http.MethodDelete:
+		s.databaseDelete(w, branch, database)
+	default:
+		writeNotFound(w, "route")
+	}
+}
+
+func (s *Server) databaseCreate(w http.ResponseWriter, branch *Branch, body []byte) {
+	var data databaseMutationRequest
+	if !decodeBody(w, body, &data) {
+		return
+	}
+
+	if data.Database.Name == "" {
+		writeError(w, http.StatusBadRequest, "", "database name is required")
+		return
+	}
+
+	if s.branchDatabase(branch.ID, data.Database.Name) != nil {
+		writeError(w, http.StatusConflict, "", fmt.Sprintf("database %s already exists", data.Database.Name))
+		return
+	}
+
+	if s.branchRole(branch.ID, data.Database.OwnerName) == nil {
+		writeError(w, http.StatusBadRequest, "", fmt.Sprintf("role not found: %s", data.Database.OwnerName))
+		return
+	}
+
+	database := s.databaseInsert(branch, data.Database.Name, data.Database.OwnerName)
+
+	writeJSON(w, http.StatusCreated, databaseResponse{Database: *database, Operations: s.branchConfigOperations(branch)})
+}
+
+func (s *Server) databaseUpdate(w http.ResponseWriter, branch *Branch, database *Database, body []byte) {
+	var data databaseMutationRequest
+	if !decodeBody(w, body, &data) {
+		return
+	}
+
+	if data.Database.Name != "" && data.Database.Name != database.Name && s.branchDatabase(branch.ID, data.Database.Name) != nil {
+		writeError(w, http.StatusConflict, "", fmt.Sprintf("database %s already exists", data.Database.Name))
+		return
+	}
+
+	if data.Database.OwnerName != "" && s.branchRole(branch.ID, data.Database.OwnerName) == nil {
+		writeError(w, http.StatusBadRequest, "", fmt.Sprintf("role not found: %s", data.Database.OwnerName))
+		return
+	}
+
+	if data.Database.Name != "" {
+		database.Name = data.Database.Name
+	}
+	if data.Database.OwnerName != "" {
+		database.OwnerName = data.Database.OwnerName
+	}
+	database.UpdatedAt = time.Now().UTC()
+
+	writeJSON(w, http.StatusOK, databaseResponse{Database: *database, Operations: s.branchConfigOperations(branch)})
+}
+
+func (s *Server) databaseDelete(w http.ResponseWriter, branch *Branch, database *Database) {
+	databases := []*Database{}
+	for _, other := range s.databases[branch.ID] {
+		if other != database {
+			databases = append(databases, other)
+		}
+	}
+	s.databases[branch.ID] = databases
+
+	writeJSON(w, http.StatusOK, databaseResponse{Database: *database, Operations: s.branchConfigOperations(branch)})
+}
@@ -0,0 +1,273 @@
+package neonApiTest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Endpoint mirrors a compute endpoint object of the Neon API v2.
+type Endpoint struct {
+	ID                    string    `json:"id"`
+	ProjectID             string    `json:"project_id"`
+	BranchID              string    `json:"branch_id"`
+	Host                  string    `json:"host"`
+	RegionID              string    `json:"region_id"`
+	Type                  string    `json:"type"`
+	CurrentState          string    `json:"current_state"`
+	AutoscalingLimitMinCu float64   `json:"autoscaling_limit_min_cu"`
+	AutoscalingLimitMaxCu float64   `json:"autoscaling_limit_max_cu"`
+	SuspendTimeoutSeconds int       `json:"suspend_timeout_seconds"`
+	PoolerEnabled         bool      `json:"pooler_enabled"`
+	PoolerMode            string    `json:"pooler_mode"`
+	Disabled              bool      `json:"disabled"`
+	CreatedAt             time.Time `json:"created_at"`
+	UpdatedAt             time.Time `json:"updated_at"`
+}
+
+type endpointResponse struct {
+	Endpoint   Endpoint    `json:"endpoint"`
+	Operations []Operation `json:"operations"`
+}
+
+type endpointListResponse struct {
+	Endpoints []Endpoint `json:"endpoints"`
+}
+
+type endpointCreateRequest struct {
+	Endpoint struct {
+		BranchID              string  `json:"branch_id"`
+		Type                  string  `json:"type"`
+		RegionID              string  `json:"region_id"`
+		AutoscalingLimitMinCu float64 `json:"autoscaling_limit_min_cu"`
+		AutoscalingLimitMaxCu float64 `json:"autoscaling_limit_max_cu"`
+		SuspendTimeoutSeconds int     `json:"suspend_timeout_seconds"`
+		PoolerEnabled         bool    `json:"pooler_enabled"`
+		PoolerMode            string  `json:"pooler_mode"`
+		Disabled              bool    `json:"disabled"`
+	} `json:"endpoint"`
+}
+
+type endpointUpdateRequest struct {
+	Endpoint struct {
+		BranchID              string   `json:"branch_id"`
+		AutoscalingLimitMinCu *float64 `json:"autoscaling_limit_min_cu"`
+		AutoscalingLimitMaxCu *float64 `json:"autoscaling_limit_max_cu"`
+		SuspendTimeoutSeconds *int     `json:"suspend_timeout_seconds"`
+		PoolerEnabled         *bool    `json:"pooler_enabled"`
+		PoolerMode            string   `json:"pooler_mode"`
+		Disabled              *bool    `json:"disabled"`
+	} `json:"endpoint"`
+}
+
+// Endpoints returns copies of the endpoints of a project ordered by creation.
+func (s *Server) Endpoints(projectID string) []Endpoint {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.projectEndpoints(projectID)
+}
+
+func (s *Server) projectEndpoints(projectID string) []Endpoint {
+	endpoints := []Endpoint{}
+	for _, endpoint := range s.endpoints {
+		if endpoint.ProjectID == projectID {
+			endpoints = append(endpoints, *endpoint)
+		}
+	}
+	sort.Slice(endpoints, func(i, j int) bool { return endpoints[i].ID < endpoints[j].ID })
+	return endpoints
+}
+
+// endpointInsert stores a new endpoint of the project, filling in the fields the API computes.
+func (s *Server) endpointInsert(project *Project, endpoint Endpoint) *Endpoint {
+	now := time.Now().UTC()
+
+	endpoint.ID = s.newID("ep")
+	endpoint.ProjectID = project.ID
+	endpoint.RegionID = project.RegionID
+	endpoint.Host = fmt.Sprintf("%s.%s.aws.neon.tech", endpoint.ID, strings.TrimPrefix(project.RegionID, project.PlatformID+"-"))
+	endpoint.CurrentState = "idle"
+	endpoint.CreatedAt = now
+	endpoint.UpdatedAt = now
+	if endpoint.AutoscalingLimitMinCu == 0 {
+		endpoint.AutoscalingLimitMinCu = 0.25
+	}
+	if endpoint.AutoscalingLimitMaxCu == 0 {
+		endpoint.AutoscalingLimitMaxCu = endpoint.AutoscalingLimitMinCu
+	}
+	if endpoint.PoolerMode == "" {
+		endpoint.PoolerMode = "transaction"
+	}
+
+	s.endpoints[endpoint.ID] = &endpoint
+	return &endpoint
+}
+
+func (s *Server) projectEndpoint(w http.ResponseWriter, project *Project, endpointID string) (*Endpoint, bool) {
+	endpoint, ok := s.endpoints[endpointID]
+	if !ok || endpoint.ProjectID != project.ID {
+		writeNotFound(w, "endpoint")
+		return nil, false
+	}
+	return endpoint, true
+}
+
+// endpointValidate checks an endpoint about to be stored and writes an error response if it is invalid.
+func (s *Server) endpointValidate(w http.ResponseWriter, project *Project, endpoint Endpoint) bool {
+	if branch, ok := s.branches[endpoint.BranchID]; !ok || branch.ProjectID != project.ID {
+		writeError(w, http.StatusBadRequest, "", fmt.Sprintf("branch not found: %s", endpoint.BranchID))
+		return false
+	}
+
+	if endpoint.Type != "read_write" && endpoint.Type != "read_only" {
+		writeError(w, http.StatusBadRequest, "", fmt.Sprintf("endpoint type is invalid: %s", endpoint.Type))
+		return false
+	}
+
+	if endpoint.AutoscalingLimitMinCu > endpoint.AutoscalingLimitMaxCu {
+		writeError(w, http.StatusBadRequest, "", "autoscaling_limit_min_cu must not be greater than autoscaling_limit_max_cu")
+		return false
+	}
+
+	if endpoint.PoolerMode != "transaction" {
+		writeError(w, http.StatusBadRequest, "", fmt.Sprintf("pooler mode is invalid: %s", endpoint.PoolerMode))
+		return false
+	}
+
+	if endpoint.Type == "read_write" {
+		for _, other := range s.endpoints {
+			if other.ID != endpoint.ID && other.BranchID == endpoint.BranchID && other.Type == "read_write" {
+				writeError(w, http.StatusConflict, "", fmt.Sprintf("read_write endpoint already exists for branch: %s", endpoint.BranchID))
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// routeEndpoints handles /api/v2/projects/{project_id}/endpoints/...
+func (s *Server) routeEndpoints(w http.ResponseWriter, r *http.Request, project *Project, segments []string, body []byte) {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, endpointListResponse{Endpoints: s.projectEndpoints(project.ID)})
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		s.endpointCreate(w, project, body)
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		if endpoint, ok := s.projectEndpoint(w, project, segments[0]); ok {
+			writeJSON(w, http.StatusOK, endpointResponse{Endpoint: *endpoint})
+		}
+	case len(segments) == 1 && r.Method == http.MethodPatch:
+		s.endpointUpdate(w, project, segments[0], body)
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		s.endpointDelete(w, project, segments[0])
+	default:
+		writeNotFound(w, "route")
+	}
+}
+
+func (s *Server) endpointCreate(w http.ResponseWriter, project *Project, body []byte) {
+	var data endpointCreateRequest
+	if !decodeBody(w, body, &data) {
+		return
+	}
+
+	if data.Endpoint.RegionID != "" && data.Endpoint.RegionID != project.RegionID {
+		writeError(w, http.StatusBadRequest, "", fmt.Sprintf("endpoint region must match project region: %s", project.RegionID))
+		return
+	}
+
+	endpoint := Endpoint{
+		BranchID:              data.Endpoint.BranchID,
+		Type:                  data.Endpoint.Type,
+		AutoscalingLimitMinCu: data.Endpoint.AutoscalingLimitMinCu,
+		AutoscalingLimitMaxCu: data.Endpoint.AutoscalingLimitMaxCu,
+		SuspendTimeoutSeconds: data.Endpoint.SuspendTimeoutSeconds,
+		PoolerEnabled:         data.Endpoint.PoolerEnabled,
+		PoolerMode:            data.Endpoint.PoolerMode,
+		Disabled:              data.Endpoint.Disabled,
+	}
+	if endpoint.AutoscalingLimitMinCu == 0 {
+		endpoint.AutoscalingLimitMinCu = 0.25
+	}
+	if endpoint.AutoscalingLimitMaxCu == 0 {
+		endpoint.AutoscalingLimitMaxCu = endpoint.AutoscalingLimitMinCu
+	}
+	if endpoint.PoolerMode == "" {
+		endpoint.PoolerMode = "transaction"
+	}
+
+	if !s.endpointValidate(w, project, endpoint) {
+		return
+	}
+
+	created := s.endpointInsert(project, endpoint)
+
+	writeJSON(w, http.StatusCreated, endpointResponse{
+		Endpoint:   *created,
+		Operations: []Operation{s.scheduleOperation(project.ID, created.BranchID, created.ID, "start_compute")},
+	})
+}
+
+func (s *Server) endpointUpdate(w http.ResponseWriter, project *Project, endpointID string, body []byte) {
+	endpoint, ok := s.projectEndpoint(w, project, endpointID)
+	if !ok {
+		return
+	}
+
+	var data endpointUpdateRequest
+	if !decodeBody(w, body, &data) {
+		return
+	}
+
+	updated := *endpoint
+	if data.Endpoint.BranchID != "" {
+		updated.BranchID = data.Endpoint.BranchID
+	}
+	if data.Endpoint.AutoscalingLimitMinCu != nil {
+		updated.AutoscalingLimitMinCu = *data.Endpoint.AutoscalingLimitMinCu
+	}
+	if data.Endpoint.AutoscalingLimitMaxCu != nil {
+		updated.AutoscalingLimitMaxCu = *data.Endpoint.AutoscalingLimitMaxCu
+	}
+	if data.Endpoint.SuspendTimeoutSeconds != nil {
+		updated.SuspendTimeoutSeconds = *data.Endpoint.SuspendTimeoutSeconds
+	}
+	if data.Endpoint.PoolerEnabled != nil {
+		updated.PoolerEnabled = *data.Endpoint.PoolerEnabled
+	}
+	if data.Endpoint.PoolerMode != "" {
+		updated.PoolerMode = data.Endpoint.PoolerMode
+	}
+	if data.Endpoint.Disabled != nil {
+		updated.Disabled = *data.Endpoint.Disabled
+	}
+
+	if !s.endpointValidate(w, project, updated) {
+		return
+	}
+
+	updated.UpdatedAt = time.Now().UTC()
+	*endpoint = updated
+
+	writeJSON(w, http.StatusOK, endpointResponse{
+		Endpoint:   *endpoint,
+		Operations: []Operation{s.scheduleOperation(project.ID, endpoint.BranchID, endpoint.ID, "apply_config")},
+	})
+}
+
+func (s *Server) endpointDelete(w http.ResponseWriter, project *Project, endpointID string) {
+	endpoint, ok := s.projectEndpoint(w, project, endpointID)
+	if !ok {
+		return
+	}
+
+	delete(s.endpoints, endpoint.ID)
+
+	writeJSON(w, http.StatusOK, endpointResponse{
+		Endpoint:   *endpoint,
+		Operations: []Operation{s.scheduleOperation(project.ID, endpoint.BranchID, endpoint.ID, "suspend_compute")},
+	})
+}
@@ -0,0 +1,101 @@
+package neonApiTest
+
+import (
+	"net/http"
+	"time"
+)
+
+// Operation mirrors an operation object of the Neon API.
+type Operation struct {
+	ID            string    `json:"id"`
+	ProjectID     string    `json:"project_id"`
+	BranchID      string    `json:"branch_id,omitempty"`
+	EndpointID    string    `json:"endpoint_id,omitempty"`
+	Action        string    `json:"action"`
+	Status        string    `json:"status"`
+	Error         string    `json:"error,omitempty"`
+	FailuresCount int       `json:"failures_count"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+
+	finishAt time.Time
+	failWith string
+}
+
+type operationResponse struct {
+	Operation Operation `json:"operation"`
+}
+
+// scheduleOperation records a new operation. It is reported as running until OperationDuration
+// has passed.
+func (s *Server) scheduleOperation(projectID string, branchID string, endpointID string, action string) Operation {
+	now := time.Now().UTC()
+	operation := &Operation{
+		ID:         s.newID("op"),
+		ProjectID:  projectID,
+		BranchID:   branchID,
+		EndpointID: endpointID,
+		Action:     action,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		finishAt:   now.Add(s.OperationDuration),
+		failWith:   s.failNext,
+	}
+	s.failNext = ""
+	s.operations[operation.ID] = operation
+
+	return s.operationSnapshot(operation)
+}
+
+// operationSnapshot resolves the status of an operation at the current time.
+func (s *Server) operationSnapshot(operation *Operation) Operation {
+	snapshot := *operation
+
+	switch {
+	case time.Now().Before(operation.finishAt):
+		snapshot.Status = "running"
+	case operation.failWith != "":
+		snapshot.Status = "failed"
+		snapshot.Error = operation.failWith
+		snapshot.FailuresCount = 1
+		snapshot.UpdatedAt = operation.finishAt
+	default:
+		snapshot.Status = "finished"
+		snapshot.UpdatedAt = operation.finishAt
+	}
+	return snapshot
+}
+
+func (s *Server) projectLocked(projectID string) bool {
+	for _, operation := range s.operations {
+		if operation.ProjectID == projectID && s.operationSnapshot(operation).Status == "running" {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) branchBusy(branchID string) bool {
+	for _, operation := range s.operations {
+		if operation.BranchID == branchID && s.operationSnapshot(operation).Status == "running" {
+			return true
+		}
+	}
+	return false
+}
+
+// routeOperations handles /api/v2/projects/{project_id}/operations/...
+func (s *Server) routeOperations(w http.ResponseWriter, r *http.Request, project *Project, segments []string) {
+	if len(segments) != 1 || r.Method != http.MethodGet {
+		writeNotFound(w, "route")
+		return
+	}
+
+	operation, ok := s.operations[segments[0]]
+	if !ok || operation.ProjectID != project.ID {
+		writeNotFound(w, "operation")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, operationResponse{Operation: s.operationSnapshot(operation)})
+}
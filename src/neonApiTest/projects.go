@@ -0,0 +1,269 @@
+package neonApiTest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// defaultProjectListLimit is the page size used when a project list request sets no limit.
+const defaultProjectListLimit = 10
+
+// Project mirrors a project object of the Neon API v1.
+type Project struct {
+	ID             string            `json:"id"`
+	Name           string            `json:"name"`
+	InstanceHandle string            `json:"instance_handle"`
+	InstanceTypeID string            `json:"instance_type_id"`
+	ParentID       string            `json:"parent_id"`
+	OrgID          string            `json:"org_id,omitempty"`
+	PlatformID     string            `json:"platform_id"`
+	PlatformName   string            `json:"platform_name"`
+	RegionID       string            `json:"region_id"`
+	RegionName     string            `json:"region_name"`
+	CurrentState   string            `json:"current_state"`
+	PendingState   string            `json:"pending_state"`
+	PoolerEnabled  bool              `json:"pooler_enabled"`
+	Deleted        bool              `json:"deleted"`
+	MaxProjectSize int               `json:"max_project_size"`
+	Size           int               `json:"size"`
+	Settings       map[string]string `json:"settings"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+type projectRole struct {
+	Dsn      string `json:"dsn"`
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+type projectDatabase struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	OwnerID int    `json:"owner_id"`
+}
+
+type projectMutationResponse struct {
+	Project
+	Roles      []projectRole     `json:"roles"`
+	Databases  []projectDatabase `json:"databases"`
+	Operations []Operation       `json:"operations"`
+}
+
+type projectCreateRequest struct {
+	Project struct {
+		InstanceHandle string            `json:"instance_handle"`
+		Name           string            `json:"name"`
+		OrgID          string            `json:"org_id"`
+		PlatformID     string            `json:"platform_id"`
+		RegionID       string            `json:"region_id"`
+		Settings       map[string]string `json:"settings"`
+	} `json:"project"`
+}
+
+type projectListResponse struct {
+	Projects   []Project  `json:"projects"`
+	Pagination pagination `json:"pagination"`
+}
+
+type pagination struct {
+	Cursor string `json:"cursor"`
+}
+
+type projectUpdateRequest struct {
+	Project struct {
+		InstanceTypeID string            `json:"instance_type_id"`
+		Name           string            `json:"name"`
+		PoolerEnabled  bool              `json:"pooler_enabled"`
+		Settings       map[string]string `json:"settings"`
+	} `json:"project"`
+}
+
+// Project returns a copy of the project with the given ID.
+func (s *Server) Project(projectID string) (Project, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	project, ok := s.projects[projectID]
+	if !ok {
+		return Project{}, false
+	}
+	return *project, true
+}
+
+func (s *Server) projectCreate(w http.ResponseWriter, body []byte) {
+	var data projectCreateRequest
+	if !decodeBody(w, body, &data) {
+		return
+	}
+
+	if data.Project.PlatformID == "" {
+		data.Project.PlatformID = "aws"
+	}
+
+	// Project creation takes the region without the platform prefix the API reports.
+	region, ok := findRegion(fmt.Sprintf("%s-%s", data.Project.PlatformID, data.Project.RegionID))
+	if !ok {
+		writeError(w, http.StatusBadRequest, "", fmt.Sprintf("region is not supported: %s", data.Project.RegionID))
+		return
+	}
+
+	now := time.Now().UTC()
+	project := &Project{
+		ID:             s.newID("project"),
+		Name:           data.Project.Name,
+		InstanceHandle: data.Project.InstanceHandle,
+		InstanceTypeID: "1",
+		OrgID:          data.Project.OrgID,
+		PlatformID:     data.Project.PlatformID,
+		PlatformName:   "Amazon Web Services",
+		RegionID:       region.RegionID,
+		RegionName:     region.Name,
+		CurrentState:   "idle",
+		MaxProjectSize: 10240,
+		Settings:       data.Project.Settings,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	writeJSON(w, http.StatusCreated, s.projectProvision(project))
+}
+
+// projectProvision stores a new project along with the default branch, endpoint, role and
+// database the Neon API creates for it.
+func (s *Server) projectProvision(project *Project) projectMutationResponse {
+	s.projects[project.ID] = project
+
+	branch := s.branchInsert(project, Branch{Name: "main", Default: true})
+	endpoint := s.endpointInsert(project, Endpoint{BranchID: branch.ID, Type: "read_write"})
+	role := s.roleInsert(branch, "neondb_owner")
+	database := s.databaseInsert(branch, "neondb", role.Name)
+
+	return projectMutationResponse{
+		Project: *project,
+		Roles: []projectRole{{
+			Dsn:      fmt.Sprintf("postgres://%s:%s@%s/%s", role.Name, role.Password, endpoint.Host, database.Name),
+			ID:       s.nextNumericID(),
+			Name:     role.Name,
+			Password: role.Password,
+		}},
+		Databases: []projectDatabase{{
+			ID:   database.ID,
+			Name: database.Name,
+		}},
+		Operations: []Operation{
+			s.scheduleOperation(project.ID, branch.ID, "", "create_timeline"),
+			s.scheduleOperation(project.ID, branch.ID, endpoint.ID, "start_compute"),
+		},
+	}
+}
+
+func (s *Server) projectRead(w http.ResponseWriter, projectID string) {
+	project, ok := s.projects[projectID]
+	if !ok {
+		writeNotFound(w, "project")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, project)
+}
+
+// projectList answers a page of projects ordered by ID. The cursor is the ID of the last project
+// of the previous page.
+func (s *Server) projectList(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	limit := defaultProjectListLimit
+	if value := query.Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			writeError(w, http.StatusBadRequest, "", fmt.Sprintf("invalid limit: %s", value))
+			return
+		}
+		limit = parsed
+	}
+
+	projectIDs := make([]string, 0, len(s.projects))
+	for projectID, project := range s.projects {
+		if orgID := query.Get("org_id"); orgID != "" && project.OrgID != orgID {
+			continue
+		}
+		if projectID > query.Get("cursor") {
+			projectIDs = append(projectIDs, projectID)
+		}
+	}
+	sort.Strings(projectIDs)
+
+	if len(projectIDs) > limit {
+		projectIDs = projectIDs[:limit]
+	}
+
+	response := projectListResponse{Projects: make([]Project, 0, len(projectIDs))}
+	for _, projectID := range projectIDs {
+		response.Projects = append(response.Projects, *s.projects[projectID])
+		response.Pagination.Cursor = projectID
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) projectUpdate(w http.ResponseWriter, projectID string, body []byte) {
+	project, ok := s.projects[projectID]
+	if !ok {
+		writeNotFound(w, "project")
+		return
+	}
+
+	var data projectUpdateRequest
+	if !decodeBody(w, body, &data) {
+		return
+	}
+
+	if data.Project.Name != "" {
+		project.Name = data.Project.Name
+	}
+	if data.Project.InstanceTypeID != "" {
+		project.InstanceTypeID = data.Project.InstanceTypeID
+	}
+	if data.Project.Settings != nil {
+		project.Settings = data.Project.Settings
+	}
+	project.PoolerEnabled = data.Project.PoolerEnabled
+	project.UpdatedAt = time.Now().UTC()
+
+	writeJSON(w, http.StatusOK, projectMutationResponse{Project: *project})
+}
+
+func (s *Server) projectDelete(w http.ResponseWriter, projectID string) {
+	project, ok := s.projects[projectID]
+	if !ok {
+		writeNotFound(w, "project")
+		return
+	}
+
+	for branchID, branch := range s.branches {
+		if branch.ProjectID == projectID {
+			delete(s.branches, branchID)
+			delete(s.roles, branchID)
+			delete(s.databases, branchID)
+		}
+	}
+	for endpointID, endpoint := range s.endpoints {
+		if endpoint.ProjectID == projectID {
+			delete(s.endpoints, endpointID)
+		}
+	}
+	delete(s.projects, projectID)
+
+	project.Deleted = true
+	writeJSON(w, http.StatusOK, projectMutationResponse{Project: *project})
+}
+
+func (s *Server) nextNumericID() int {
+	s.nextID++
+	return s.nextID
+}
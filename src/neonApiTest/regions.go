@@ -0,0 +1,36 @@
+package neonApiTest
+
+import "net/http"
+
+// Region mirrors a region object of the Neon API v2.
+type Region struct {
+	RegionID string `json:"region_id"`
+	Name     string `json:"name"`
+	Default  bool   `json:"default"`
+}
+
+type regionListResponse struct {
+	Regions []Region `json:"regions"`
+}
+
+// regions lists the regions supported by the fake, in the order the API returns them.
+var regions = []Region{
+	{RegionID: "aws-us-east-2", Name: "US East (Ohio)", Default: true},
+	{RegionID: "aws-us-west-2", Name: "US West (Oregon)"},
+	{RegionID: "aws-eu-central-1", Name: "Europe (Frankfurt)"},
+	{RegionID: "aws-ap-southeast-1", Name: "Asia Pacific (Singapore)"},
+}
+
+// findRegion returns the region with the given platform-prefixed ID.
+func findRegion(regionID string) (Region, bool) {
+	for _, region := range regions {
+		if region.RegionID == regionID {
+			return region, true
+		}
+	}
+	return Region{}, false
+}
+
+func (s *Server) regionList(w http.ResponseWriter) {
+	writeJSON(w, http.StatusOK, regionListResponse{Regions: regions})
+}
@@ -0,0 +1,174 @@
+package neonApiTest
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Role mirrors a Postgres role object of the Neon API v2.
+type Role struct {
+	BranchID  string    `json:"branch_id"`
+	Name      string    `json:"name"`
+	Password  string    `json:"password,omitempty"`
+	Protected bool      `json:"protected"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type roleResponse struct {
+	Role       Role        `json:"role"`
+	Operations []Operation `json:"operations"`
+}
+
+type roleListResponse struct {
+	Roles []Role `json:"roles"`
+}
+
+type rolePasswordResponse struct {
+	Password string `json:"password"`
+}
+
+type roleCreateRequest struct {
+	Role struct {
+		Name string `json:"name"`
+	} `json:"role"`
+}
+
+// Roles returns copies of the roles of a branch, including their passwords.
+func (s *Server) Roles(branchID string) []Role {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	roles := []Role{}
+	for _, role := range s.roles[branchID] {
+		roles = append(roles, *role)
+	}
+	return roles
+}
+
+func (s *Server) roleInsert(branch *Branch, name string) *Role {
+	now := time.Now().UTC()
+	role := &Role{
+		BranchID:  branch.ID,
+		Name:      name,
+		Password:  newPassword(),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	s.roles[branch.ID] = append(s.roles[branch.ID], role)
+	return role
+}
+
+func (s *Server) branchRole(branchID string, name string) *Role {
+	for _, role := range s.roles[branchID] {
+		if role.Name == name {
+			return role
+		}
+	}
+	return nil
+}
+
+// roleSnapshot returns the role as listed by the API, which never includes the password.
+func roleSnapshot(role *Role) Role {
+	snapshot := *role
+	snapshot.Password = ""
+	return snapshot
+}
+
+// branchConfigOperations schedules the operations that apply a configuration change to every
+// endpoint of the branch.
+func (s *Server) branchConfigOperations(branch *Branch) []Operation {
+	operations := []Operation{}
+	for _, endpoint := range s.projectEndpoints(branch.ProjectID) {
+		if endpoint.BranchID == branch.ID {
+			operations = append(operations, s.scheduleOperation(branch.ProjectID, branch.ID, endpoint.ID, "apply_config"))
+		}
+	}
+	return operations
+}
+
+// routeRoles handles /api/v2/projects/{project_id}/branches/{branch_id}/roles/...
+func (s *Server) routeRoles(w http.ResponseWriter, r *http.Request, project *Project, branchID string, segments []string, body []byte) {
+	branch, ok := s.projectBranch(w, project, branchID)
+	if !ok {
+		return
+	}
+
+	if len(segments) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			roles := []Role{}
+			for _, role := range s.roles[branch.ID] {
+				roles = append(roles, roleSnapshot(role))
+			}
+			writeJSON(w, http.StatusOK, roleListResponse{Roles: roles})
+		case http.MethodPost:
+			s.roleCreate(w, branch, body)
+		default:
+			writeNotFound(w, "route")
+		}
+		return
+	}
+
+	role := s.branchRole(branch.ID, segments[0])
+	if role == nil {
+		writeNotFound(w, "role")
+		return
+	}
+
+	switch {
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, roleResponse{Role: roleSnapshot(role)})
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		s.roleDelete(w, branch, role)
+	case len(segments) == 2 && segments[1] == "reset_password" && r.Method == http.MethodPost:
+		role.Password = newPassword()
+		role.UpdatedAt = time.Now().UTC()
+		writeJSON(w, http.StatusOK, roleResponse{Role: *role, Operations: s.branchConfigOperations(branch)})
+	case len(segments) == 2 && segments[1] == "reveal_password" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, rolePasswordResponse{Password: role.Password})
+	default:
+		writeNotFound(w, "route")
+	}
+}
+
+func (s *Server) roleCreate(w http.ResponseWriter, branch *Branch, body []byte) {
+	var data roleCreateRequest
+	if !decodeBody(w, body, &data) {
+		return
+	}
+
+	if data.Role.Name == "" {
+		writeError(w, http.StatusBadRequest, "", "role name is required")
+		return
+	}
+
+	if s.branchRole(branch.ID, data.Role.Name) != nil {
+		writeError(w, http.StatusConflict, "", fmt.Sprintf("role %s already exists", data.Role.Name))
+		return
+	}
+
+	role := s.roleInsert(branch, data.Role.Name)
+
+	writeJSON(w, http.StatusCreated, roleResponse{Role: *role, Operations: s.branchConfigOperations(branch)})
+}
+
+func (s *Server) roleDelete(w http.ResponseWriter, branch *Branch, role *Role) {
+	for _, database := range s.databases[branch.ID] {
+		if database.OwnerName == role.Name {
+			writeError(w, http.StatusBadRequest, "", fmt.Sprintf("role %s owns database %s", role.Name, database.Name))
+			return
+		}
+	}
+
+	roles := []*Role{}
+	for _, other := range s.roles[branch.ID] {
+		if other != role {
+			roles = append(roles, other)
+		}
+	}
+	s.roles[branch.ID] = roles
+
+	writeJSON(w, http.StatusOK, roleResponse{Role: roleSnapshot(role), Operations: s.branchConfigOperations(branch)})
+}
@@ -0,0 +1,310 @@
+// Package neonApiTest provides an in-memory fake of the Neon API for hermetic tests.
+//
+// The fake keeps projects, branches, endpoints, roles, databases and operations in memory and
+// answers with the same response and error shapes as the real API. Errors can be injected per
+// route to exercise 404, 423 and 429 handling.
+package neonApiTest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultAPIKey is the bearer token accepted by a new Server.
+const DefaultAPIKey = "neon-api-test-key"
+
+// RequestIDHeader is the response header carrying the ID of each request.
+const RequestIDHeader = "X-Request-Id"
+
+// Server is an httptest server emulating the Neon API.
+type Server struct {
+	*httptest.Server
+
+	// APIKey is the bearer token requests must present.
+	APIKey string
+
+	// OperationDuration is how long operations stay running after being scheduled. While a
+	// project has running operations, further mutations on it are answered with 423 Locked.
+	// Zero means operations are reported as finished immediately.
+	OperationDuration time.Duration
+
+	mu         sync.Mutex
+	nextID     int
+	projects   map[string]*Project
+	branches   map[string]*Branch
+	endpoints  map[string]*Endpoint
+	roles      map[string][]*Role
+	databases  map[string][]*Database
+	operations map[string]*Operation
+	injected   []*InjectedResponse
+	requests   []Request
+	failNext   string
+}
+
+// InjectedResponse describes an error response returned instead of handling a matching request.
+type InjectedResponse struct {
+	// Method and Path select the requests to answer. Empty values match any request.
+	Method string
+	Path   string
+
+	StatusCode int
+	Code       string
+	Message    string
+	Header     http.Header
+
+	// Times is how many matching requests are answered before the injection is dropped.
+	// Zero or less answers every matching request.
+	Times int
+}
+
+// Request records a request received by the server.
+type Request struct {
+	Method string
+	Path   string
+	Header http.Header
+	Body   string
+}
+
+type errorResponseBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// NewServer starts a fake Neon API server. Callers must Close it.
+func NewServer() *Server {
+	s := &Server{
+		APIKey:     DefaultAPIKey,
+		projects:   map[string]*Project{},
+		branches:   map[string]*Branch{},
+		endpoints:  map[string]*Endpoint{},
+		roles:      map[string][]*Role{},
+		databases:  map[string][]*Database{},
+		operations: map[string]*Operation{},
+	}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// Inject registers an error response for matching requests.
+func (s *Server) Inject(response InjectedResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.injected = append(s.injected, &response)
+}
+
+// FailNextOperation makes the next scheduled operation end in the failed state with the given error.
+func (s *Server) FailNextOperation(message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failNext = message
+}
+
+// Requests returns every request received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request{}, s.requests...)
+}
+
+// RequestCount returns how many requests matched the given method and path.
+func (s *Server) RequestCount(method string, path string) int {
+	count := 0
+	for _, request := range s.Requests() {
+		if request.Method == method && request.Path == path {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Header: r.Header.Clone(),
+		Body:   string(body),
+	})
+
+	w.Header().Set(RequestIDHeader, fmt.Sprintf("req-%06d", len(s.requests)))
+
+	if r.Header.Get("Authorization") != "Bearer "+s.APIKey {
+		writeError(w, http.StatusUnauthorized, "", "authentication required")
+		return
+	}
+
+	if s.answerInjected(w, r) {
+		return
+	}
+
+	s.route(w, r, body)
+}
+
+func (s *Server) answerInjected(w http.ResponseWriter, r *http.Request) bool {
+	for i, injected := range s.injected {
+		if injected.Method != "" && injected.Method != r.Method {
+			continue
+		}
+		if injected.Path != "" && injected.Path != r.URL.Path {
+			continue
+		}
+
+		if injected.Times > 0 {
+			injected.Times--
+			if injected.Times == 0 {
+				s.injected = append(s.injected[:i], s.injected[i+1:]...)
+			}
+		}
+
+		for key, values := range injected.Header {
+			for _, value := range values {
+				w.Header().Add(key, value)
+			}
+		}
+		writeError(w, injected.StatusCode, injected.Code, injected.Message)
+		return true
+	}
+	return false
+}
+
+func (s *Server) route(w http.ResponseWriter, r *http.Request, body []byte) {
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/"), "/"), "/")
+
+	if len(segments) == 2 && segments[0] == "v2" && segments[1] == "regions" && r.Method == http.MethodGet {
+		s.regionList(w)
+		return
+	}
+
+	if len(segments) < 2 || segments[1] != "projects" {
+		writeNotFound(w, "route")
+		return
+	}
+
+	switch segments[0] {
+	case "v1":
+		s.routeV1(w, r, segments[2:], body)
+	case "v2":
+		s.routeV2(w, r, segments[2:], body)
+	default:
+		writeNotFound(w, "route")
+	}
+}
+
+// routeV1 handles /api/v1/projects/...
+func (s *Server) routeV1(w http.ResponseWriter, r *http.Request, segments []string, body []byte) {
+	if len(segments) > 0 && r.Method != http.MethodGet && s.projectLocked(segments[0]) {
+		writeLocked(w)
+		return
+	}
+
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		s.projectCreate(w, body)
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		s.projectRead(w, segments[0])
+	case len(segments) == 1 && r.Method == http.MethodPatch:
+		s.projectUpdate(w, segments[0], body)
+	case len(segments) == 2 && segments[1] == "delete" && r.Method == http.MethodPost:
+		s.projectDelete(w, segments[0])
+	default:
+		writeNotFound(w, "route")
+	}
+}
+
+// routeV2 handles /api/v2/projects and /api/v2/projects/{project_id}/...
+func (s *Server) routeV2(w http.ResponseWriter, r *http.Request, segments []string, body []byte) {
+	if len(segments) == 0 && r.Method == http.MethodGet {
+		s.projectList(w, r)
+		return
+	}
+
+	if len(segments) < 2 {
+		writeNotFound(w, "route")
+		return
+	}
+
+	project, ok := s.projects[segments[0]]
+	if !ok {
+		writeNotFound(w, "project")
+		return
+	}
+
+	if r.Method != http.MethodGet && s.projectLocked(project.ID) {
+		writeLocked(w)
+		return
+	}
+
+	resource, segments := segments[1], segments[2:]
+
+	switch resource {
+	case "operations":
+		s.routeOperations(w, r, project, segments)
+	case "branches":
+		if len(segments) >= 2 && segments[1] == "roles" {
+			s.routeRoles(w, r, project, segments[0], segments[2:], body)
+			return
+		}
+		if len(segments) >= 2 && segments[1] == "databases" {
+			s.routeDatabases(w, r, project, segments[0], segments[2:], body)
+			return
+		}
+		s.routeBranches(w, r, project, segments, body)
+	case "endpoints":
+		s.routeEndpoints(w, r, project, segments, body)
+	default:
+		writeNotFound(w, "route")
+	}
+}
+
+func (s *Server) newID(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s-test-%06d", prefix, s.nextID)
+}
+
+func newPassword() string {
+	return fmt.Sprintf("npg_%016x", rand.Int63())
+}
+
+func decodeBody(w http.ResponseWriter, body []byte, data interface{}) bool {
+	if len(body) == 0 {
+		return true
+	}
+
+	if err := json.Unmarshal(body, data); err != nil {
+		writeError(w, http.StatusBadRequest, "", fmt.Sprintf("request body is invalid: %s", err))
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, code string, message string) {
+	writeJSON(w, statusCode, errorResponseBody{Code: code, Message: message})
+}
+
+func writeLocked(w http.ResponseWriter) {
+	writeError(w, http.StatusLocked, "", "project already has running operations, scheduling of new ones is prohibited")
+}
+
+func writeNotFound(w http.ResponseWriter, object string) {
+	writeError(w, http.StatusNotFound, "", fmt.Sprintf("%s not found", object))
+}
@@ -0,0 +1,129 @@
+package neonApiTest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func doRequest(t *testing.T, server *Server, method string, path string, body string) (*http.Response, errorResponseBody) {
+	request, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	request.Header.Set("Authorization", "Bearer "+server.APIKey)
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+
+	var errorBody errorResponseBody
+	json.NewDecoder(response.Body).Decode(&errorBody)
+
+	return response, errorBody
+}
+
+func createProject(t *testing.T, server *Server) projectMutationResponse {
+	request, _ := http.NewRequest(http.MethodPost, server.URL+"/api/v1/projects", strings.NewReader(`{"project":{"name":"test","platform_id":"aws","region_id":"us-west-2"}}`))
+	request.Header.Set("Authorization", "Bearer "+server.APIKey)
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+
+	var project projectMutationResponse
+	if err := json.NewDecoder(response.Body).Decode(&project); err != nil {
+		t.Fatal(err)
+	}
+	return project
+}
+
+// TestServerReturnsNotFound verifies unknown objects are answered with a 404 error body
+func TestServerReturnsNotFound(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	response, body := doRequest(t, server, http.MethodGet, "/api/v2/projects/missing/branches", "")
+
+	if response.StatusCode != http.StatusNotFound || body.Message != "project not found" {
+		t.Errorf("Expected 404 project not found, got %d %+v", response.StatusCode, body)
+	}
+}
+
+// TestServerProvisionsDefaultBranch verifies project creation provisions the default branch, endpoint, role and database
+func TestServerProvisionsDefaultBranch(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	project := createProject(t, server)
+
+	branches := server.Branches(project.ID)
+	if len(branches) != 1 || !branches[0].Default {
+		t.Fatalf("Expected a single default branch, got %+v", branches)
+	}
+
+	if endpoints := server.Endpoints(project.ID); len(endpoints) != 1 || endpoints[0].BranchID != branches[0].ID {
+		t.Errorf("Expected a single endpoint on the default branch, got %+v", endpoints)
+	}
+
+	if len(project.Roles) != 1 || !strings.Contains(project.Roles[0].Dsn, project.Roles[0].Password) {
+		t.Errorf("Expected the default role with its DSN, got %+v", project.Roles)
+	}
+}
+
+// TestServerLocksProjectWithRunningOperations verifies mutations are rejected while operations run
+func TestServerLocksProjectWithRunningOperations(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.OperationDuration = time.Hour
+
+	project := createProject(t, server)
+
+	if project.Operations[0].Status != "running" {
+		t.Errorf("Expected operation to be running, got %s", project.Operations[0].Status)
+	}
+
+	response, _ := doRequest(t, server, http.MethodPost, "/api/v2/projects/"+project.ID+"/branches", "{}")
+	if response.StatusCode != http.StatusLocked {
+		t.Errorf("Expected 423 while operations run, got %d", response.StatusCode)
+	}
+
+	response, _ = doRequest(t, server, http.MethodGet, "/api/v2/projects/"+project.ID+"/branches", "")
+	if response.StatusCode != http.StatusOK {
+		t.Errorf("Expected reads to succeed while operations run, got %d", response.StatusCode)
+	}
+}
+
+// TestServerInjectsResponses verifies injected responses are returned the requested number of times
+func TestServerInjectsResponses(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.Inject(InjectedResponse{
+		Path:       "/api/v1/projects/some-project",
+		StatusCode: http.StatusTooManyRequests,
+		Message:    "rate limit exceeded",
+		Header:     http.Header{"Retry-After": []string{"1"}},
+		Times:      1,
+	})
+
+	response, body := doRequest(t, server, http.MethodGet, "/api/v1/projects/some-project", "")
+	if response.StatusCode != http.StatusTooManyRequests || response.Header.Get("Retry-After") != "1" || body.Message != "rate limit exceeded" {
+		t.Errorf("Expected injected 429, got %d %+v", response.StatusCode, body)
+	}
+
+	response, _ = doRequest(t, server, http.MethodGet, "/api/v1/projects/some-project", "")
+	if response.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected injection to be dropped, got %d", response.StatusCode)
+	}
+
+	if count := server.RequestCount(http.MethodGet, "/api/v1/projects/some-project"); count != 2 {
+		t.Errorf("Expected 2 recorded requests, got %d", count)
+	}
+}